@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Policy",type=string,JSONPath=`.spec.runPolicy`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterLuaScript is the Schema for the clusterluascripts API. It is the cluster-scoped
+// variant of LuaScript. ConfigMaps and Secrets it refers to are looked up in the
+// namespace of the operator and its triggers select objects in all namespaces by default.
+type ClusterLuaScript struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LuaScriptSpec   `json:"spec,omitempty"`
+	Status LuaScriptStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterLuaScriptList contains a list of ClusterLuaScript.
+type ClusterLuaScriptList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterLuaScript `json:"items"`
+}
+
+// GetScriptSpec returns the spec shared by all script kinds.
+func (s *ClusterLuaScript) GetScriptSpec() *ScriptSpec {
+	return &s.Spec.ScriptSpec
+}
+
+// GetScriptStatus returns the status shared by all script kinds.
+func (s *ClusterLuaScript) GetScriptStatus() *ScriptStatus {
+	return &s.Status.ScriptStatus
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterLuaScript{}, &ClusterLuaScriptList{})
+}
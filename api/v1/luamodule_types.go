@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ModuleLanguage is the language the code of a module is written in.
+// +kubebuilder:validation:Enum=Lua;MoonScript
+type ModuleLanguage string
+
+const (
+	// ModuleLua is a module written in Lua.
+	ModuleLua ModuleLanguage = "Lua"
+	// ModuleMoonScript is a module written in MoonScript, it is compiled to Lua when required.
+	ModuleMoonScript ModuleLanguage = "MoonScript"
+)
+
+// LuaModuleSpec defines the desired state of LuaModule and ClusterLuaModule.
+type LuaModuleSpec struct {
+	// Language of the code. Defaults to Lua.
+	// +optional
+	// +kubebuilder:default=Lua
+	Language ModuleLanguage `json:"language,omitempty"`
+
+	// Code is the source code of the module. Like a file on package.path, it is
+	// executed once per script execution and returns the value of the module.
+	// +kubebuilder:validation:MinLength=1
+	Code string `json:"code"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Language",type=string,JSONPath=`.spec.language`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// LuaModule is the Schema for the luamodules API.
+// Scripts in the same namespace load it by its name, e.g. require("team.helpers").
+type LuaModule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec LuaModuleSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LuaModuleList contains a list of LuaModule.
+type LuaModuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LuaModule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LuaModule{}, &LuaModuleList{})
+}
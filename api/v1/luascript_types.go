@@ -25,16 +25,19 @@ import (
 
 // LuaScriptSpec defines the desired state of LuaScript.
 type LuaScriptSpec struct {
-	Code string `json:"code,omitempty"`
+	ScriptSpec `json:",inline"`
 }
 
 // LuaScriptStatus defines the observed state of LuaScript.
 type LuaScriptStatus struct {
-	Output string `json:"output,omitempty"`
+	ScriptStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Policy",type=string,JSONPath=`.spec.runPolicy`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // LuaScript is the Schema for the luascripts API.
 type LuaScript struct {
@@ -54,6 +57,16 @@ type LuaScriptList struct {
 	Items           []LuaScript `json:"items"`
 }
 
+// GetScriptSpec returns the spec shared by all script kinds.
+func (s *LuaScript) GetScriptSpec() *ScriptSpec {
+	return &s.Spec.ScriptSpec
+}
+
+// GetScriptStatus returns the status shared by all script kinds.
+func (s *LuaScript) GetScriptStatus() *ScriptStatus {
+	return &s.Status.ScriptStatus
+}
+
 func init() {
 	SchemeBuilder.Register(&LuaScript{}, &LuaScriptList{})
 }
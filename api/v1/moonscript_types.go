@@ -25,16 +25,19 @@ import (
 
 // MoonScriptSpec defines the desired state of MoonScript.
 type MoonScriptSpec struct {
-	Code string `json:"code,omitempty"`
+	ScriptSpec `json:",inline"`
 }
 
 // MoonScriptStatus defines the observed state of MoonScript.
 type MoonScriptStatus struct {
-	Output string `json:"output,omitempty"`
+	ScriptStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Policy",type=string,JSONPath=`.spec.runPolicy`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // MoonScript is the Schema for the moonscripts API.
 type MoonScript struct {
@@ -54,6 +57,16 @@ type MoonScriptList struct {
 	Items           []MoonScript `json:"items"`
 }
 
+// GetScriptSpec returns the spec shared by all script kinds.
+func (s *MoonScript) GetScriptSpec() *ScriptSpec {
+	return &s.Spec.ScriptSpec
+}
+
+// GetScriptStatus returns the status shared by all script kinds.
+func (s *MoonScript) GetScriptStatus() *ScriptStatus {
+	return &s.Status.ScriptStatus
+}
+
 func init() {
 	SchemeBuilder.Register(&MoonScript{}, &MoonScriptList{})
 }
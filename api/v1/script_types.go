@@ -0,0 +1,593 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RunPolicy describes when a script is executed.
+// +kubebuilder:validation:Enum=Once;OnChange;Always
+type RunPolicy string
+
+const (
+	// RunOnce executes a script a single time after it has been created.
+	RunOnce RunPolicy = "Once"
+	// RunOnChange executes a script whenever its spec or code changes.
+	RunOnChange RunPolicy = "OnChange"
+	// RunAlways executes a script on every reconciliation, including resyncs and operator restarts,
+	// except those caused by the completion of its own execution.
+	RunAlways RunPolicy = "Always"
+)
+
+// ConcurrencyPolicy describes how overlapping executions of a scheduled script are treated.
+// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+type ConcurrencyPolicy string
+
+const (
+	// AllowConcurrent starts scheduled executions even if previous executions are still running.
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+	// ForbidConcurrent postpones scheduled executions until previous executions finished.
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+	// ReplaceConcurrent cancels running executions in favor of the scheduled execution.
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
+// CatchUpPolicy describes how scheduled executions are treated that were missed,
+// e.g. because the operator was not running at the scheduled time.
+// +kubebuilder:validation:Enum=Skip;Latest;All
+type CatchUpPolicy string
+
+const (
+	// CatchUpSkip drops missed executions and waits for the next scheduled time.
+	CatchUpSkip CatchUpPolicy = "Skip"
+	// CatchUpLatest executes once for the most recent missed schedule.
+	CatchUpLatest CatchUpPolicy = "Latest"
+	// CatchUpAll executes once for every missed schedule, oldest first.
+	CatchUpAll CatchUpPolicy = "All"
+)
+
+// ScheduleSpec describes when a script is executed periodically.
+type ScheduleSpec struct {
+	// Cron is the schedule in standard five field cron format, e.g. "*/5 * * * *".
+	// Descriptors such as "@hourly" are supported as well.
+	// +kubebuilder:validation:MinLength=1
+	Cron string `json:"cron"`
+
+	// TimeZone is the IANA name of the time zone the schedule is interpreted in. Defaults to UTC.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// StartingDeadlineSeconds is the deadline in seconds for starting an execution
+	// that missed its scheduled time for any reason. Executions that cannot be started
+	// within the deadline are dropped.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// ConcurrencyPolicy describes how overlapping executions are treated. Defaults to Allow.
+	// +optional
+	// +kubebuilder:default=Allow
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// CatchUpPolicy describes how missed executions are treated. Defaults to Latest.
+	// +optional
+	// +kubebuilder:default=Latest
+	CatchUpPolicy CatchUpPolicy `json:"catchUpPolicy,omitempty"`
+}
+
+// TriggerEventType is the type of change to a watched object.
+// +kubebuilder:validation:Enum=Added;Modified;Deleted
+type TriggerEventType string
+
+const (
+	// TriggerAdded is the event of an object being created.
+	TriggerAdded TriggerEventType = "Added"
+	// TriggerModified is the event of an object being updated.
+	TriggerModified TriggerEventType = "Modified"
+	// TriggerDeleted is the event of an object being deleted.
+	TriggerDeleted TriggerEventType = "Deleted"
+)
+
+// TriggerSpec executes a script for changes to the objects it selects.
+type TriggerSpec struct {
+	// APIVersion of the watched objects, e.g. "v1" or "apps/v1".
+	// +kubebuilder:validation:MinLength=1
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the watched objects, e.g. "ConfigMap".
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+
+	// Namespace of the watched objects. Defaults to the namespace of the script or to all
+	// namespaces for cluster-scoped scripts, "*" selects objects in all namespaces.
+	// Namespaced scripts can only watch objects in their own namespace, so cluster-scoped
+	// kinds are reserved for cluster-scoped scripts. Ignored for cluster-scoped kinds.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// LabelSelector restricts the watched objects by labels.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// FieldSelector restricts the watched objects by the values of arbitrary fields,
+	// e.g. "metadata.name=example,status.phase!=Running".
+	// +optional
+	FieldSelector string `json:"fieldSelector,omitempty"`
+
+	// Events restricts the types of changes the script is executed for. Defaults to all.
+	// +optional
+	Events []TriggerEventType `json:"events,omitempty"`
+}
+
+// ScriptSource is where the code of a script is loaded from. Exactly one of its fields must be set.
+// +kubebuilder:validation:MinProperties=1
+// +kubebuilder:validation:MaxProperties=1
+type ScriptSource struct {
+	// Inline is the source code of the script.
+	// +optional
+	Inline string `json:"inline,omitempty"`
+
+	// ConfigMapKeyRef selects a key of a ConfigMap in the namespace of the script holding the source code.
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef selects a key of a Secret in the namespace of the script holding the source code.
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// ScriptArg is a named input of a script.
+// +kubebuilder:validation:XValidation:rule="has(self.value) != has(self.valueFrom)",message="exactly one of value and valueFrom must be set"
+type ScriptArg struct {
+	// Name of the argument, the key in the args table of the script.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Value is a literal value of any JSON type.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Value *apiextensionsv1.JSON `json:"value,omitempty"`
+
+	// ValueFrom is the source of the value of the argument.
+	// +optional
+	ValueFrom *ArgSource `json:"valueFrom,omitempty"`
+}
+
+// ArgSource is where the value of an argument is loaded from. Exactly one of its fields must be set.
+// +kubebuilder:validation:MinProperties=1
+// +kubebuilder:validation:MaxProperties=1
+type ArgSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap in the namespace of the script.
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef selects a key of a Secret in the namespace of the script.
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// FieldRef selects a field of the script, e.g. metadata.name or metadata.labels['app'].
+	// +optional
+	FieldRef *corev1.ObjectFieldSelector `json:"fieldRef,omitempty"`
+}
+
+// ResultConfigMapSpec describes the ConfigMap large results of a script are written to.
+type ResultConfigMapSpec struct {
+	// Name of the ConfigMap in the namespace of the script. It is created if it does not exist.
+	// Existing ConfigMaps are only updated if they are owned by the script, otherwise
+	// the execution fails. The result is written to the key result.json.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// SizeThreshold is the size in bytes of the JSON encoded result above which it is
+	// written to the ConfigMap instead of status. Defaults to 4096.
+	// +optional
+	// +kubebuilder:default=4096
+	// +kubebuilder:validation:Minimum=0
+	SizeThreshold *int32 `json:"sizeThreshold,omitempty"`
+}
+
+// ResourceLimits restricts the resources a single execution of a script may use.
+// Executions exceeding a limit are terminated.
+type ResourceLimits struct {
+	// CallStackSize is the maximum depth of nested function calls. Defaults to 256.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	CallStackSize *int32 `json:"callStackSize,omitempty"`
+
+	// RegistrySize is the maximum number of values on the Lua stack. Defaults to 5120.
+	// +optional
+	// +kubebuilder:validation:Minimum=128
+	RegistrySize *int32 `json:"registrySize,omitempty"`
+
+	// Instructions is the maximum number of Lua instructions executed, which bounds
+	// the CPU time of an execution independently of the load of the operator.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Instructions *int64 `json:"instructions,omitempty"`
+
+	// Memory is the maximum approximate size of the values allocated by the script, e.g. "64Mi".
+	// Defaults to the operator-wide default.
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+}
+
+// RetryableError is a class of errors failed executions are retried for.
+// +kubebuilder:validation:Enum=Conflict;Throttled;ServerError;Timeout;ScriptError
+type RetryableError string
+
+const (
+	// RetryOnConflict retries executions that failed because an object was modified concurrently.
+	RetryOnConflict RetryableError = "Conflict"
+	// RetryOnThrottled retries executions whose requests were rejected by the API server with 429.
+	RetryOnThrottled RetryableError = "Throttled"
+	// RetryOnServerError retries executions whose requests failed with 5xx or did not reach the API server.
+	RetryOnServerError RetryableError = "ServerError"
+	// RetryOnTimeout retries executions that exceeded their timeout or whose requests timed out.
+	RetryOnTimeout RetryableError = "Timeout"
+	// RetryOnScriptError retries executions that failed with any other error raised by the script.
+	// Code that does not compile is never retried.
+	RetryOnScriptError RetryableError = "ScriptError"
+)
+
+// RetryPolicy describes how failed executions of a script are retried.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries of a failed execution. Defaults to 3.
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+
+	// InitialBackoff is the delay before the first retry, e.g. "10s". It doubles with
+	// every further retry. Defaults to 10s.
+	// +optional
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+
+	// MaxBackoff caps the delay between retries. Defaults to 5m.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+
+	// RetryOn are the classes of errors that are retried.
+	// Defaults to Conflict, Throttled, ServerError and Timeout.
+	// +optional
+	// +listType=set
+	RetryOn []RetryableError `json:"retryOn,omitempty"`
+}
+
+// RunAtAnnotation forces a new execution of a script whenever its value changes,
+// regardless of the run policy. Any value may be used, a timestamp is customary.
+const RunAtAnnotation = "scropt.io/run-at"
+
+// SkipOnDeleteAnnotation set to "true" lets a deleted script go without executing
+// spec.onDelete, e.g. when the onDelete code keeps failing.
+const SkipOnDeleteAnnotation = "scropt.io/skip-on-delete"
+
+// MigratingAnnotation is set by the operator on v1 scripts that are being migrated
+// to v2 Scripts. It suspends the script until it has been replaced.
+const MigratingAnnotation = "scropt.io/migrating"
+
+// OnDeleteFinalizer is held by scripts with spec.onDelete until the onDelete code
+// succeeded after the script was deleted.
+const OnDeleteFinalizer = "scripts.scropt.io/on-delete"
+
+// ScriptSpec is the desired state shared by all script kinds.
+// +kubebuilder:validation:XValidation:rule="!(has(self.code) && has(self.source))",message="code and source are mutually exclusive"
+type ScriptSpec struct {
+	// Code is the source code of the script.
+	// +optional
+	Code string `json:"code,omitempty"`
+
+	// Source is where the source code of the script is loaded from, as an alternative to code.
+	// The script is executed again according to its run policy when the referenced code changes.
+	// +optional
+	Source *ScriptSource `json:"source,omitempty"`
+
+	// OnDelete is code executed when the script is deleted, in the language and with the
+	// bindings of its code, e.g. to clean up resources created by it. Deletion is blocked
+	// until it succeeded, unless the scropt.io/skip-on-delete annotation is set to "true".
+	// +optional
+	OnDelete string `json:"onDelete,omitempty"`
+
+	// RunPolicy describes when the script is executed. Defaults to OnChange.
+	// +optional
+	// +kubebuilder:default=OnChange
+	RunPolicy RunPolicy `json:"runPolicy,omitempty"`
+
+	// Timeout is the maximum duration of an execution, e.g. "30s". Executions exceeding it
+	// are cancelled. Defaults to the operator-wide default and is capped by the operator-wide maximum.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Limits restricts the resources an execution may use.
+	// +optional
+	Limits *ResourceLimits `json:"limits,omitempty"`
+
+	// RetryPolicy retries failed executions with exponential backoff.
+	// Failed executions are not retried if it is not set.
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+
+	// SuccessfulRunsHistoryLimit is the number of ScriptRuns of successful executions to keep.
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	SuccessfulRunsHistoryLimit *int32 `json:"successfulRunsHistoryLimit,omitempty"`
+
+	// FailedRunsHistoryLimit is the number of ScriptRuns of failed executions to keep.
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	FailedRunsHistoryLimit *int32 `json:"failedRunsHistoryLimit,omitempty"`
+
+	// RunTTL deletes ScriptRuns this long after their execution finished, even if
+	// they are within the history limits.
+	// +optional
+	RunTTL *metav1.Duration `json:"runTTL,omitempty"`
+
+	// ServiceAccountName is the name of a ServiceAccount whose identity the script
+	// uses to access the API server, so that its permissions are subject to the RBAC
+	// rules bound to the ServiceAccount. The ServiceAccount must exist in the namespace
+	// of the script, or of the operator for cluster-scoped scripts. Scripts without
+	// service account use the identity of the operator.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// DryRun executes the script with a client that submits all changes with dryRun=All,
+	// so that they pass admission but are not persisted. The changes are recorded in
+	// status.mutations instead.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Suspend stops all executions of the script. Running executions are cancelled,
+	// scheduled executions and events are skipped until the script is resumed.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Schedule executes the script periodically. If schedule or triggers are set,
+	// the run policy is ignored and the script is only executed at the scheduled
+	// times, for events or on request through the scropt.io/run-at annotation.
+	// +optional
+	Schedule *ScheduleSpec `json:"schedule,omitempty"`
+
+	// Args are exposed to the script as the read-only global table "args".
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Args []ScriptArg `json:"args,omitempty"`
+
+	// ArgsSchema is an OpenAPI v3 schema the args table is validated against before execution.
+	// Values loaded from ConfigMaps, Secrets and fields are strings, unless the schema
+	// declares a different type for the argument, in which case they are parsed as JSON.
+	// +optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ArgsSchema *apiextensionsv1.JSON `json:"argsSchema,omitempty"`
+
+	// ResultSchema is an OpenAPI v3 schema the value returned by the script is validated against.
+	// +optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ResultSchema *apiextensionsv1.JSON `json:"resultSchema,omitempty"`
+
+	// ResultConfigMap receives results too large to be stored in status.
+	// +optional
+	ResultConfigMap *ResultConfigMapSpec `json:"resultConfigMap,omitempty"`
+
+	// Triggers execute the script for every change to the objects they select.
+	// The change is exposed to the script as the global table "event" with the
+	// fields "type", "object" and "oldObject".
+	// +optional
+	Triggers []TriggerSpec `json:"triggers,omitempty"`
+}
+
+// ScriptPhase is a high-level summary of where a script is in its execution lifecycle.
+// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed;Suspended
+type ScriptPhase string
+
+const (
+	// ScriptPending means the script has been accepted but not executed yet.
+	ScriptPending ScriptPhase = "Pending"
+	// ScriptRunning means the script is currently being executed.
+	ScriptRunning ScriptPhase = "Running"
+	// ScriptSucceeded means the last execution completed without error.
+	ScriptSucceeded ScriptPhase = "Succeeded"
+	// ScriptFailed means the last execution did not compile or raised an error.
+	ScriptFailed ScriptPhase = "Failed"
+	// ScriptSuspended means the script is not executed because it or the operator is suspended.
+	ScriptSuspended ScriptPhase = "Suspended"
+)
+
+// Condition types reported on scripts.
+const (
+	// ConditionRunning is True while an execution is in progress.
+	ConditionRunning = "Running"
+	// ConditionSucceeded is True if the last execution completed without error
+	// and False if it failed. It is Unknown while the script is pending or running.
+	ConditionSucceeded = "Succeeded"
+	// ConditionDeletionBlocked is True if the onDelete code of a deleted script failed
+	// and Unknown while it is being executed.
+	ConditionDeletionBlocked = "DeletionBlocked"
+	// ConditionSuspended is True while the script is not executed because of spec.suspend,
+	// the global pause of the operator or its migration to a v2 Script.
+	ConditionSuspended = "Suspended"
+)
+
+// Condition reasons reported on scripts.
+const (
+	ReasonPending               = "Pending"
+	ReasonExecuting             = "Executing"
+	ReasonCompleted             = "Completed"
+	ReasonCompileFailed         = "CompileFailed"
+	ReasonExecFailed            = "ExecutionFailed"
+	ReasonInvalidSchedule       = "InvalidSchedule"
+	ReasonInvalidTrigger        = "InvalidTrigger"
+	ReasonSourceNotFound        = "SourceNotFound"
+	ReasonInvalidArgs           = "InvalidArgs"
+	ReasonInvalidResult         = "InvalidResult"
+	ReasonTimedOut              = "TimedOut"
+	ReasonCancelled             = "Cancelled"
+	ReasonResourceLimitExceeded = "ResourceLimitExceeded"
+	ReasonFinalizing            = "Finalizing"
+	ReasonOnDeleteFailed        = "OnDeleteFailed"
+	ReasonSuspended             = "Suspended"
+	ReasonPaused                = "Paused"
+	ReasonMigrating             = "Migrating"
+	ReasonResumed               = "Resumed"
+)
+
+// ScriptError describes why the last execution of a script failed.
+type ScriptError struct {
+	// Message is the error raised by the compiler or the Lua runtime.
+	Message string `json:"message"`
+
+	// Line is the line of the script code the error refers to, if known.
+	// +optional
+	Line int32 `json:"line,omitempty"`
+
+	// Column is the column of the script code the error refers to, if known.
+	// +optional
+	Column int32 `json:"column,omitempty"`
+}
+
+// ScriptStatus is the observed state shared by all script kinds.
+type ScriptStatus struct {
+	// Phase is a high-level summary of the last execution.
+	// +optional
+	Phase ScriptPhase `json:"phase,omitempty"`
+
+	// Conditions represent the latest available observations of the script's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// ObservedGeneration is the metadata.generation of the script that was last executed.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// StartTime is the time the last execution started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the last execution finished, successfully or not.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// CodeHash is the sha256 of the code that was last executed.
+	// +optional
+	CodeHash string `json:"codeHash,omitempty"`
+
+	// SourceRevision identifies the ConfigMap or Secret the code that was last executed
+	// has been loaded from, as kind/name@resourceVersion.
+	// +optional
+	SourceRevision string `json:"sourceRevision,omitempty"`
+
+	// Error is set if the last execution failed.
+	// +optional
+	Error *ScriptError `json:"error,omitempty"`
+
+	// Result is the JSON encoded value returned by the last execution, unless it
+	// has been written to the result ConfigMap.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Result *apiextensionsv1.JSON `json:"result,omitempty"`
+
+	// ResultConfigMap is the name of the ConfigMap the result of the last execution has been written to.
+	// +optional
+	ResultConfigMap string `json:"resultConfigMap,omitempty"`
+
+	// Attempts is the number of times the last execution has been attempted, including retries.
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// NextRetryTime is the time the last execution is retried at, if it failed and is retried.
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+
+	// LastRun is the name of the ScriptRun of the last execution.
+	// +optional
+	LastRun string `json:"lastRun,omitempty"`
+
+	// DryRun is true if the last execution was a dry run.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Mutations are the changes the last execution would have made, if it was a dry run.
+	// At most 100 mutations are recorded.
+	// +optional
+	// +listType=atomic
+	Mutations []Mutation `json:"mutations,omitempty"`
+
+	// LastScheduleTime is the most recent scheduled time an execution was started for.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// NextScheduleTime is the next time the script is scheduled for.
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// ObservedRunAt is the value of the scropt.io/run-at annotation at the last execution.
+	// +optional
+	ObservedRunAt string `json:"observedRunAt,omitempty"`
+}
+
+// Mutation is a change to an object requested by a dry-run execution.
+type Mutation struct {
+	// Verb is one of create, update, patch, delete or deletecollection.
+	Verb string `json:"verb"`
+
+	// APIVersion of the object.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the object.
+	Kind string `json:"kind"`
+
+	// Namespace of the object, empty for cluster-scoped objects.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the object, empty for deletecollection.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Subresource changed instead of the object, such as status.
+	// +optional
+	Subresource string `json:"subresource,omitempty"`
+}
+
+// String formats the error message prefixed with its position, if known.
+func (e *ScriptError) String() string {
+	switch {
+	case e.Line > 0 && e.Column > 0:
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+	case e.Line > 0:
+		return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+	default:
+		return e.Message
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Labels of ScriptRuns identifying the script they were created for. The name
+// of the script is truncated to the 63 characters allowed in label values.
+const (
+	ScriptRunScriptLabel    = "scropt.io/script"
+	ScriptRunScriptUIDLabel = "scropt.io/script-uid"
+)
+
+// ScriptReference refers to a script of any kind.
+type ScriptReference struct {
+	// APIVersion of the script.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the script.
+	Kind string `json:"kind"`
+
+	// Name of the script.
+	Name string `json:"name"`
+
+	// Namespace of the script, empty for cluster-scoped scripts.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ScriptRunSpec describes the execution a ScriptRun records.
+type ScriptRunSpec struct {
+	// ScriptRef refers to the executed script.
+	ScriptRef ScriptReference `json:"scriptRef"`
+
+	// Reason why the script was executed.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Generation is the metadata.generation of the script that was executed.
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+
+	// CodeHash is the sha256 of the executed code.
+	// +optional
+	CodeHash string `json:"codeHash,omitempty"`
+
+	// SourceRevision identifies the ConfigMap or Secret the code has been loaded from,
+	// as kind/name@resourceVersion.
+	// +optional
+	SourceRevision string `json:"sourceRevision,omitempty"`
+
+	// Attempt is the number of the attempt of the execution, greater than 1 for retries.
+	// +optional
+	Attempt int32 `json:"attempt,omitempty"`
+
+	// DryRun is true if the execution was a dry run.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// TTLAfterFinished is the duration after which the ScriptRun is deleted once the execution finished.
+	// +optional
+	TTLAfterFinished *metav1.Duration `json:"ttlAfterFinished,omitempty"`
+}
+
+// ScriptRunStatus is the outcome of the execution.
+type ScriptRunStatus struct {
+	// Phase is Running until the execution finished, Succeeded or Failed afterwards.
+	// +optional
+	Phase ScriptPhase `json:"phase,omitempty"`
+
+	// StartTime is when the execution started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the execution finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Error is set if the execution failed.
+	// +optional
+	Error *ScriptError `json:"error,omitempty"`
+
+	// Result is the JSON encoded value returned by the execution, unless it
+	// has been written to the result ConfigMap.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Result *apiextensionsv1.JSON `json:"result,omitempty"`
+
+	// ResultConfigMap is the name of the ConfigMap the result has been written to.
+	// It only holds the result of the most recent execution of the script.
+	// +optional
+	ResultConfigMap string `json:"resultConfigMap,omitempty"`
+
+	// Output is what the script printed through print and log.
+	// +optional
+	Output string `json:"output,omitempty"`
+
+	// OutputTruncated is true if the output exceeded its maximum size and has been truncated.
+	// +optional
+	OutputTruncated bool `json:"outputTruncated,omitempty"`
+
+	// Mutations are the changes the execution would have made, if it was a dry run.
+	// +optional
+	// +listType=atomic
+	Mutations []Mutation `json:"mutations,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Kind",type=string,JSONPath=`.spec.scriptRef.kind`
+// +kubebuilder:printcolumn:name="Script",type=string,JSONPath=`.spec.scriptRef.name`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.spec.reason`,priority=1
+// +kubebuilder:printcolumn:name="Started",type=date,JSONPath=`.status.startTime`
+
+// ScriptRun is the Schema for the scriptruns API. A ScriptRun is created for every
+// execution of a script and owned by the script. ScriptRuns of cluster-scoped
+// scripts are created in the namespace of the operator.
+type ScriptRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ScriptRunSpec   `json:"spec,omitempty"`
+	Status ScriptRunStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScriptRunList contains a list of ScriptRun.
+type ScriptRunList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ScriptRun `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ScriptRun{}, &ScriptRunList{})
+}
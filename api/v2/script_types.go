@@ -0,0 +1,92 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+)
+
+// Language is the programming language of a script.
+// +kubebuilder:validation:Enum=lua;moonscript
+type Language string
+
+const (
+	// LanguageLua scripts are executed as is.
+	LanguageLua Language = "lua"
+	// LanguageMoonScript scripts are compiled to Lua before execution.
+	LanguageMoonScript Language = "moonscript"
+)
+
+// MigratedFromAnnotation is set on Scripts migrated from a v1 kind to the name of that kind.
+const MigratedFromAnnotation = "scropt.io/migrated-from"
+
+// ScriptSpec defines the desired state of Script.
+type ScriptSpec struct {
+	// Language of the code and onDelete code of the script. Defaults to lua.
+	// +optional
+	// +kubebuilder:default=lua
+	Language Language `json:"language,omitempty"`
+
+	scriptsv1.ScriptSpec `json:",inline"`
+}
+
+// ScriptStatus defines the observed state of Script.
+type ScriptStatus struct {
+	scriptsv1.ScriptStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Language",type=string,JSONPath=`.spec.language`
+// +kubebuilder:printcolumn:name="Policy",type=string,JSONPath=`.spec.runPolicy`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Script is the Schema for the scripts API. It supersedes the v1 LuaScript
+// and MoonScript kinds, which only differ by language.
+type Script struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ScriptSpec   `json:"spec,omitempty"`
+	Status ScriptStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScriptList contains a list of Script.
+type ScriptList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Script `json:"items"`
+}
+
+// GetScriptSpec returns the spec shared by all script kinds.
+func (s *Script) GetScriptSpec() *scriptsv1.ScriptSpec {
+	return &s.Spec.ScriptSpec
+}
+
+// GetScriptStatus returns the status shared by all script kinds.
+func (s *Script) GetScriptStatus() *scriptsv1.ScriptStatus {
+	return &s.Status.ScriptStatus
+}
+
+func init() {
+	SchemeBuilder.Register(&Script{}, &ScriptList{})
+}
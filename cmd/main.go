@@ -21,11 +21,13 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -38,7 +40,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	scriptsv1 "github.com/veith4f/scropt/api/v1"
+	scriptsv2 "github.com/veith4f/scropt/api/v2"
 	"github.com/veith4f/scropt/internal/controller"
+	webhookscriptsv1 "github.com/veith4f/scropt/internal/webhook/v1"
+	webhookscriptsv2 "github.com/veith4f/scropt/internal/webhook/v2"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -51,6 +56,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(scriptsv1.AddToScheme(scheme))
+	utilruntime.Must(scriptsv2.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -63,6 +69,11 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var defaultScriptTimeout, maxScriptTimeout time.Duration
+	var defaultScriptMemoryLimit string
+	var migrateV1Scripts bool
+	var dryRun bool
+	var operatorConfigMap string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -81,6 +92,19 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.DurationVar(&defaultScriptTimeout, "default-script-timeout", 10*time.Minute,
+		"The timeout of script executions if the script does not set spec.timeout. Use 0 for no timeout.")
+	flag.DurationVar(&maxScriptTimeout, "max-script-timeout", time.Hour,
+		"The maximum timeout of script executions, spec.timeout is capped to it. Use 0 for no maximum.")
+	flag.StringVar(&defaultScriptMemoryLimit, "default-script-memory-limit", "256Mi",
+		"The memory limit of script executions if the script does not set spec.limits.memory. Use 0 for no limit.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"If set, all scripts are executed as dry run, their changes are recorded in status but not persisted.")
+	flag.BoolVar(&migrateV1Scripts, "migrate-v1-scripts", false,
+		"If set, LuaScripts and MoonScripts are replaced by v2 Scripts of the same name.")
+	flag.StringVar(&operatorConfigMap, "config-map", "scropt-config",
+		"The name of the ConfigMap in the namespace of the operator whose key paused set to \"true\" suspends all scripts. "+
+			"Use an empty name to disable the global pause.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -89,6 +113,12 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	defaultMemoryLimit, err := resource.ParseQuantity(defaultScriptMemoryLimit)
+	if err != nil {
+		setupLog.Error(err, "invalid default-script-memory-limit")
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -202,20 +232,60 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = (&controller.LuaScriptReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "LuaScript")
-		os.Exit(1)
+	for _, kind := range controller.ScriptKinds {
+		if err = (&controller.ScriptReconciler{
+			Client:             mgr.GetClient(),
+			Scheme:             mgr.GetScheme(),
+			Kind:               kind,
+			DefaultTimeout:     defaultScriptTimeout,
+			MaxTimeout:         maxScriptTimeout,
+			DefaultMemoryLimit: defaultMemoryLimit.Value(),
+			DryRun:             dryRun,
+			ConfigMapName:      operatorConfigMap,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", kind.Name)
+			os.Exit(1)
+		}
 	}
-	if err = (&controller.MoonScriptReconciler{
+	if err = (&controller.ScriptRunReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "MoonScript")
+		setupLog.Error(err, "unable to create controller", "controller", "ScriptRun")
 		os.Exit(1)
 	}
+	if migrateV1Scripts {
+		if err = (&controller.ScriptMigrationReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ScriptMigration")
+			os.Exit(1)
+		}
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookscriptsv1.SetupLuaScriptWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "LuaScript")
+			os.Exit(1)
+		}
+		if err = webhookscriptsv1.SetupMoonScriptWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "MoonScript")
+			os.Exit(1)
+		}
+		if err = webhookscriptsv1.SetupClusterLuaScriptWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterLuaScript")
+			os.Exit(1)
+		}
+		if err = webhookscriptsv1.SetupClusterMoonScriptWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterMoonScript")
+			os.Exit(1)
+		}
+		if err = webhookscriptsv2.SetupScriptWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Script")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
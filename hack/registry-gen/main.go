@@ -0,0 +1,246 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// registry-gen generates the registry of the Go types and functions exposed to
+// scripts by internal/lua, together with the Lua namespaces of the API groups.
+// The registry is the only metadata used by the bindings at runtime, such that
+// scripts run without Go sources or toolchain.
+//
+// Usage:
+//
+//	go run ./hack/registry-gen -o internal/lua/zz_generated.registry.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/types"
+	"log"
+	"os"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/go/packages"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+)
+
+// bound is a package whose exported declarations are registered.
+type bound struct {
+	// Path is the import path of the package.
+	Path string
+	// Alias is the name the package is imported as by the generated code.
+	Alias string
+	// Namespace is the global the types of the package are exposed as,
+	// empty for packages exposed through objects such as ctx and client.
+	Namespace string
+	// Funcs registers the functions of the package in addition to its structs.
+	Funcs bool
+
+	// Decls are the registered declarations by name, set by load.
+	Decls []decl
+}
+
+// objectBindings are the packages exposed through the objects ctx and client.
+var objectBindings = []*bound{
+	{Path: "context", Alias: "context", Funcs: true},
+	{Path: "sigs.k8s.io/controller-runtime/pkg/client", Alias: "client", Funcs: true},
+}
+
+// reservedNames are globals of scripts other than API namespaces. API namespaces
+// that would shadow them are suffixed with their version, e.g. discoveryv1.
+var reservedNames = []string{
+	"print", "log", "ctx", "discovery", "client", "dynamic",
+	"_G", "coroutine", "debug", "io", "math", "os", "package", "string", "table", "channel",
+}
+
+// schemeBuilder registers the APIs exposed to scripts as namespaces. Only the v1
+// API of this operator is exposed, v2 objects are accessed through dynamic.
+var schemeBuilder = runtime.NewSchemeBuilder(clientgoscheme.AddToScheme, scriptsv1.AddToScheme)
+
+// apiBindings returns a package per API group registered by schemeBuilder, of its
+// most stable and recent version. API groups are exposed as namespaces named
+// after the first label of their group, e.g. apps.Deployment, and the core group
+// as core.
+func apiBindings() ([]*bound, error) {
+	scheme := runtime.NewScheme()
+	if err := schemeBuilder.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	// package path of the types of each group version, other than those of meta/v1 added to every group version
+	metaPath := reflect.TypeOf(metav1.Status{}).PkgPath()
+	paths := make(map[schema.GroupVersion]string)
+	for gvk, typ := range scheme.AllKnownTypes() {
+		if gvk.Version != runtime.APIVersionInternal && typ.PkgPath() != metaPath {
+			paths[gvk.GroupVersion()] = typ.PkgPath()
+		}
+	}
+
+	preferred := make(map[string]schema.GroupVersion)
+	for gv := range paths {
+		if cur, ok := preferred[gv.Group]; !ok || version.CompareKubeAwareVersionStrings(gv.Version, cur.Version) > 0 {
+			preferred[gv.Group] = gv
+		}
+	}
+
+	var bindings []*bound
+	names := make(map[string]string)
+	for _, gv := range preferred {
+		name, _, _ := strings.Cut(gv.Group, ".")
+		if name == "" {
+			name = "core"
+		}
+		alias := name + gv.Version
+		if slices.Contains(reservedNames, name) {
+			name = alias
+		}
+		if other, ok := names[name]; ok {
+			return nil, fmt.Errorf("groups %s and %s are both exposed as %s", other, gv.Group, name)
+		}
+		names[name] = gv.Group
+		bindings = append(bindings, &bound{Path: paths[gv], Alias: alias, Namespace: name})
+	}
+	slices.SortFunc(bindings, func(a, b *bound) int { return strings.Compare(a.Namespace, b.Namespace) })
+	return bindings, nil
+}
+
+// decl is a registered declaration.
+type decl struct {
+	// Name is the name of the declaration in its package.
+	Name string
+	// Value is the Go expression of the declaration.
+	Value string
+}
+
+var tmpl = template.Must(template.New("registry").Funcs(template.FuncMap{"base": path.Base}).Parse(`// Code generated by registry-gen. DO NOT EDIT.
+
+package lua
+
+import (
+{{- range .}}
+	{{if ne .Alias (base .Path)}}{{.Alias}} {{end}}"{{.Path}}"
+{{- end}}
+)
+
+// apiNamespaces are the namespaces of the API groups exposed to scripts.
+var apiNamespaces = []apiNamespace{
+{{- range .}}{{if .Namespace}}
+	{name: "{{.Namespace}}", pkg: "{{.Path}}"},
+{{- end}}{{end}}
+}
+
+// registeredPackages are the structs and functions added to the registry by package and name.
+var registeredPackages = map[string]map[string]any{
+{{- range .}}
+	"{{.Path}}": {
+	{{- range .Decls}}
+		"{{.Name}}": {{.Value}},
+	{{- end}}
+	},
+{{- end}}
+}
+`))
+
+func main() {
+	out := flag.String("o", "zz_generated.registry.go", "file to write the registry to")
+	flag.Parse()
+
+	apis, err := apiBindings()
+	if err != nil {
+		log.Fatalf("failed resolving API groups: %v", err)
+	}
+	bindings := append(objectBindings, apis...)
+	if err := load(bindings); err != nil {
+		log.Fatalf("failed loading packages: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, bindings); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("invalid registry: %v", err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// load collects the exported structs of the packages, as composite literals,
+// and their exported functions if requested, in the order of their names.
+// Generic declarations are skipped.
+func load(bindings []*bound) error {
+	paths := make([]string, len(bindings))
+	for i, b := range bindings {
+		paths[i] = b.Path
+	}
+
+	// Type check from source, rather than from export data, so that the generator
+	// does not depend on the export data format of the toolchain.
+	mode := packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedImports | packages.NeedDeps
+	pkgs, err := packages.Load(&packages.Config{Mode: mode}, paths...)
+	if err != nil {
+		return err
+	}
+	loaded := make(map[string]*packages.Package, len(pkgs))
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return fmt.Errorf("%s: %v", pkg.PkgPath, pkg.Errors)
+		}
+		loaded[pkg.PkgPath] = pkg
+	}
+
+	for _, b := range bindings {
+		pkg, ok := loaded[b.Path]
+		if !ok {
+			return fmt.Errorf("%s: not found", b.Path)
+		}
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj := scope.Lookup(name)
+			if !obj.Exported() {
+				continue
+			}
+			switch obj := obj.(type) {
+			case *types.TypeName:
+				named, ok := types.Unalias(obj.Type()).(*types.Named)
+				if !ok || named.TypeParams().Len() > 0 {
+					continue
+				}
+				if _, ok := named.Underlying().(*types.Struct); ok {
+					b.Decls = append(b.Decls, decl{name, "&" + b.Alias + "." + name + "{}"})
+				}
+			case *types.Func:
+				if b.Funcs && obj.Type().(*types.Signature).TypeParams().Len() == 0 {
+					b.Decls = append(b.Decls, decl{name, b.Alias + "." + name})
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	openapi "k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scrv1 "github.com/veith4f/scropt/api/v1"
+)
+
+// mapFieldPath matches field paths selecting a single label or annotation, e.g. metadata.labels['app']
+var mapFieldPath = regexp.MustCompile(`^metadata\.(labels|annotations)\['(.+)'\]$`)
+
+// errInvalidArgs is returned by resolveArgs if the args of a script cannot
+// be resolved or do not conform to the args schema.
+type errInvalidArgs struct {
+	msg string
+}
+
+func (e *errInvalidArgs) Error() string {
+	return e.msg
+}
+
+func invalidArgs(format string, args ...any) error {
+	return &errInvalidArgs{msg: fmt.Sprintf(format, args...)}
+}
+
+// resolveArgs loads the values of the args of a script and validates them against its args schema.
+// Values of optional references that do not exist are omitted.
+func resolveArgs(ctx context.Context, c client.Client, script scriptObject) (map[string]any, error) {
+	spec := script.GetScriptSpec()
+
+	var schema *openapi.Schema
+	if spec.ArgsSchema != nil {
+		schema = &openapi.Schema{}
+		if err := json.Unmarshal(spec.ArgsSchema.Raw, schema); err != nil {
+			return nil, invalidArgs("invalid argsSchema: %v", err)
+		}
+	}
+
+	args := make(map[string]any, len(spec.Args))
+	for _, arg := range spec.Args {
+		if arg.Value != nil {
+			var value any
+			if err := json.Unmarshal(arg.Value.Raw, &value); err != nil {
+				return nil, invalidArgs("arg %s: invalid value: %v", arg.Name, err)
+			}
+			args[arg.Name] = value
+			continue
+		}
+
+		value, found, err := resolveArgSource(ctx, c, script, arg.ValueFrom)
+		if err != nil {
+			return nil, fmt.Errorf("arg %s: %w", arg.Name, err)
+		}
+		if !found {
+			continue
+		}
+		if typed, ok := parseTyped(schema, arg.Name, value); ok {
+			args[arg.Name] = typed
+		} else {
+			args[arg.Name] = value
+		}
+	}
+
+	if schema != nil {
+		if err := validate.AgainstSchema(schema, args, strfmt.Default); err != nil {
+			return nil, invalidArgs("args do not match argsSchema: %v", err)
+		}
+	}
+	return args, nil
+}
+
+// resolveArgSource loads the value of an arg from a ConfigMap, Secret or field of the script.
+func resolveArgSource(ctx context.Context, c client.Client, script scriptObject, src *scrv1.ArgSource) (string, bool, error) {
+	switch {
+	case src == nil:
+		return "", false, invalidArgs("neither value nor valueFrom is set")
+
+	case src.ConfigMapKeyRef != nil:
+		ref := src.ConfigMapKeyRef
+		cm := &corev1.ConfigMap{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: referenceNamespace(script), Name: ref.Name}, cm); err != nil {
+			return argNotFound(err, ref.Optional, "ConfigMap %s not found", ref.Name)
+		}
+		value, ok := cm.Data[ref.Key]
+		if !ok {
+			return argNotFound(nil, ref.Optional, "key %s not found in ConfigMap %s", ref.Key, ref.Name)
+		}
+		return value, true, nil
+
+	case src.SecretKeyRef != nil:
+		ref := src.SecretKeyRef
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: referenceNamespace(script), Name: ref.Name}, secret); err != nil {
+			return argNotFound(err, ref.Optional, "Secret %s not found", ref.Name)
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			return argNotFound(nil, ref.Optional, "key %s not found in Secret %s", ref.Key, ref.Name)
+		}
+		return string(value), true, nil
+
+	case src.FieldRef != nil:
+		value, err := fieldValue(script, src.FieldRef.FieldPath)
+		return value, err == nil, err
+
+	default:
+		return "", false, invalidArgs("valueFrom is empty")
+	}
+}
+
+// argNotFound returns the result of resolveArgSource for a reference that could not be resolved.
+func argNotFound(err error, optional *bool, format string, args ...any) (string, bool, error) {
+	_, err = sourceNotFound(err, optional, format, args...)
+	var notFound *errSourceNotFound
+	if errors.As(err, &notFound) {
+		return "", false, &errInvalidArgs{msg: notFound.msg}
+	}
+	return "", false, err
+}
+
+// fieldValue returns the value of a field of a script selected by a dotted path
+// or a label or annotation as in the downward API.
+func fieldValue(script scriptObject, path string) (string, error) {
+	if m := mapFieldPath.FindStringSubmatch(path); m != nil {
+		if m[1] == "labels" {
+			return script.GetLabels()[m[2]], nil
+		}
+		return script.GetAnnotations()[m[2]], nil
+	}
+
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(script)
+	if err != nil {
+		return "", err
+	}
+	value, found, err := unstructured.NestedFieldNoCopy(obj, strings.Split(path, ".")...)
+	if err != nil || !found {
+		return "", invalidArgs("field %s not found", path)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+// parseTyped parses a string value as JSON if the schema declares a type other than string for the arg.
+func parseTyped(schema *openapi.Schema, name, value string) (any, bool) {
+	if schema == nil {
+		return nil, false
+	}
+	prop, ok := schema.Properties[name]
+	if !ok || len(prop.Type) == 0 || prop.Type.Contains("string") {
+		return nil, false
+	}
+	var typed any
+	if err := json.Unmarshal([]byte(value), &typed); err != nil {
+		return nil, false
+	}
+	return typed, true
+}
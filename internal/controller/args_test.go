@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+)
+
+var _ = Describe("Script args", func() {
+	ctx := context.Background()
+
+	c := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"},
+		Data:       map[string]string{"replicas": "3", "mode": "fast"},
+	}).Build()
+
+	newScript := func(schema string, args ...scriptsv1.ScriptArg) *scriptsv1.LuaScript {
+		script := &scriptsv1.LuaScript{ObjectMeta: metav1.ObjectMeta{
+			Name:      "example",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "example"},
+		}}
+		script.Spec.Args = args
+		if schema != "" {
+			script.Spec.ArgsSchema = &apiextensionsv1.JSON{Raw: []byte(schema)}
+		}
+		return script
+	}
+	fromConfigMap := func(name, key string) scriptsv1.ScriptArg {
+		return scriptsv1.ScriptArg{Name: name, ValueFrom: &scriptsv1.ArgSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+			Key:                  key,
+		}}}
+	}
+
+	It("should resolve literal values, references and fields", func() {
+		args, err := resolveArgs(ctx, c, newScript("",
+			scriptsv1.ScriptArg{Name: "list", Value: &apiextensionsv1.JSON{Raw: []byte(`[1, "two"]`)}},
+			fromConfigMap("mode", "mode"),
+			scriptsv1.ScriptArg{Name: "app", ValueFrom: &scriptsv1.ArgSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels['app']"}}},
+			scriptsv1.ScriptArg{Name: "name", ValueFrom: &scriptsv1.ArgSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+		))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(args).To(Equal(map[string]any{
+			"list": []any{float64(1), "two"},
+			"mode": "fast",
+			"app":  "example",
+			"name": "example",
+		}))
+	})
+
+	It("should parse referenced values typed by the schema", func() {
+		args, err := resolveArgs(ctx, c, newScript(`{"properties": {"replicas": {"type": "integer"}}}`,
+			fromConfigMap("replicas", "replicas"),
+		))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(args).To(HaveKeyWithValue("replicas", float64(3)))
+	})
+
+	It("should reject args not matching the schema", func() {
+		_, err := resolveArgs(ctx, c, newScript(`{"required": ["replicas"], "properties": {"mode": {"enum": ["slow"]}}}`,
+			fromConfigMap("mode", "mode"),
+		))
+		var invalid *errInvalidArgs
+		Expect(errors.As(err, &invalid)).To(BeTrue())
+	})
+
+	It("should reject missing references", func() {
+		_, err := resolveArgs(ctx, c, newScript("", fromConfigMap("missing", "missing")))
+		var invalid *errInvalidArgs
+		Expect(errors.As(err, &invalid)).To(BeTrue())
+	})
+})
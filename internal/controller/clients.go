@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// scriptClients are the clients exposed to an execution of a script.
+type scriptClients struct {
+	client client.Client
+	// nil if the runner has no config
+	discovery discovery.DiscoveryInterface
+	// nil if the runner has no config
+	dynamic dynamic.Interface
+	// records the mutations of dry runs, nil otherwise
+	recorder *mutationRecorder
+}
+
+// clients returns the clients of an execution of a script. Scripts with a
+// service account access the API server impersonating it, others with the
+// identity of the operator through c. Dry runs record their mutations.
+func (r *runner) clients(ctx context.Context, c client.Client, script scriptObject) (scriptClients, error) {
+	clients := scriptClients{client: c}
+	config := r.config
+
+	if name := script.GetScriptSpec().ServiceAccountName; name != "" {
+		sa := &corev1.ServiceAccount{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: referenceNamespace(script), Name: name}, sa); err != nil {
+			if apierrors.IsNotFound(err) {
+				return clients, fmt.Errorf("ServiceAccount %s not found", name)
+			}
+			return clients, err
+		}
+		if config == nil {
+			return clients, fmt.Errorf("cannot impersonate ServiceAccount %s without client config", name)
+		}
+		config = impersonate(config, sa)
+		var err error
+		if clients.client, err = client.New(config, client.Options{Scheme: c.Scheme(), Mapper: c.RESTMapper()}); err != nil {
+			return clients, fmt.Errorf("failed creating client for ServiceAccount %s: %w", name, err)
+		}
+	}
+
+	if config != nil {
+		var err error
+		if clients.discovery, err = discovery.NewDiscoveryClientForConfig(config); err != nil {
+			return clients, fmt.Errorf("failed creating discovery client: %w", err)
+		}
+		if clients.dynamic, err = dynamic.NewForConfig(config); err != nil {
+			return clients, fmt.Errorf("failed creating dynamic client: %w", err)
+		}
+	}
+
+	if r.dryRun || script.GetScriptSpec().DryRun {
+		clients.recorder = newMutationRecorder(clients.client)
+		clients.client = clients.recorder
+		if clients.dynamic != nil {
+			clients.dynamic = &dynamicRecorder{Interface: clients.dynamic, recorder: clients.recorder}
+		}
+	}
+	return clients, nil
+}
+
+// impersonate returns a copy of config that authenticates as a ServiceAccount.
+func impersonate(config *rest.Config, sa *corev1.ServiceAccount) *rest.Config {
+	config = rest.CopyConfig(config)
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: fmt.Sprintf("system:serviceaccount:%s:%s", sa.Namespace, sa.Name),
+	}
+	return config
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+)
+
+var _ = Describe("Script clients", func() {
+	ctx := context.Background()
+
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "runner", Namespace: "default"}}
+	c := fake.NewClientBuilder().WithObjects(sa).Build()
+	r := &runner{config: &rest.Config{Host: "https://localhost:6443"}}
+
+	newScript := func(serviceAccountName string) *scriptsv1.LuaScript {
+		script := &scriptsv1.LuaScript{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}
+		script.Spec.ServiceAccountName = serviceAccountName
+		return script
+	}
+
+	It("should use the client of the operator without service account", func() {
+		clients, err := r.clients(ctx, c, newScript(""))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clients.client).To(BeIdenticalTo(c))
+		Expect(clients.discovery).NotTo(BeNil())
+	})
+
+	It("should impersonate the service account of a script", func() {
+		clients, err := r.clients(ctx, c, newScript("runner"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clients.client).NotTo(BeIdenticalTo(c))
+		Expect(impersonate(r.config, sa).Impersonate.UserName).To(Equal("system:serviceaccount:default:runner"))
+		Expect(r.config.Impersonate.UserName).To(BeEmpty())
+	})
+
+	It("should fail for missing service accounts", func() {
+		_, err := r.clients(ctx, c, newScript("missing"))
+		Expect(err).To(MatchError("ServiceAccount missing not found"))
+	})
+})
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+)
+
+var _ = Describe("ClusterLuaScript Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name: resourceName,
+		}
+		clusterluascript := &scriptsv1.ClusterLuaScript{}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind ClusterLuaScript")
+			err := k8sClient.Get(ctx, typeNamespacedName, clusterluascript)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &scriptsv1.ClusterLuaScript{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: resourceName,
+					},
+					// TODO(user): Specify other spec details if needed.
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			// TODO(user): Cleanup logic after each test, like removing the resource instance.
+			resource := &scriptsv1.ClusterLuaScript{}
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Cleanup the specific resource instance ClusterLuaScript")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+		It("should successfully reconcile the resource", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &ScriptReconciler{
+				Kind:   ClusterLuaScriptKind,
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Reporting a script without code as pending")
+			resource := &scriptsv1.ClusterLuaScript{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal(scriptsv1.ScriptPending))
+			Expect(resource.Status.ObservedGeneration).To(Equal(resource.Generation))
+			Expect(meta.IsStatusConditionPresentAndEqual(resource.Status.Conditions,
+				scriptsv1.ConditionSucceeded, metav1.ConditionUnknown)).To(BeTrue())
+		})
+	})
+})
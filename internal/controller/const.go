@@ -1,5 +0,0 @@
-package controller
-
-const (
-	CONTROLLER_SCRIPT_EXECUTED = "Executed"
-)
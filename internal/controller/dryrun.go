@@ -0,0 +1,262 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scrv1 "github.com/veith4f/scropt/api/v1"
+)
+
+// maxMutations is the maximum number of mutations recorded per dry-run execution.
+const maxMutations = 100
+
+// mutationRecorder is the client of dry-run executions. It submits all changes
+// with dryRun=All and records those admitted by the API server.
+type mutationRecorder struct {
+	client.Client
+
+	mu        sync.Mutex
+	mutations []scrv1.Mutation
+}
+
+// newMutationRecorder returns a dry-run client on top of c.
+func newMutationRecorder(c client.Client) *mutationRecorder {
+	return &mutationRecorder{Client: client.NewDryRunClient(c)}
+}
+
+// record adds a mutation of obj unless the request failed.
+func (r *mutationRecorder) record(err error, verb string, obj runtime.Object, namespace, name, subresource string) error {
+	if err != nil {
+		return err
+	}
+	mutation := scrv1.Mutation{Verb: verb, Namespace: namespace, Name: name, Subresource: subresource}
+	if gvk, gvkErr := r.GroupVersionKindFor(obj); gvkErr == nil {
+		mutation.APIVersion, mutation.Kind = gvk.GroupVersion().String(), gvk.Kind
+	}
+	r.add(mutation)
+	return nil
+}
+
+// recordResource is like record for changes made through the dynamic client. The kind
+// is taken from obj if known, otherwise it is looked up by resource.
+func (r *mutationRecorder) recordResource(err error, verb string, resource schema.GroupVersionResource, obj *unstructured.Unstructured, namespace, name, subresource string) error {
+	if err != nil {
+		return err
+	}
+	mutation := scrv1.Mutation{Verb: verb, Namespace: namespace, Name: name, Subresource: subresource}
+	if obj != nil && obj.GetKind() != "" {
+		mutation.APIVersion, mutation.Kind = obj.GetAPIVersion(), obj.GetKind()
+	} else if gvk, gvkErr := r.RESTMapper().KindFor(resource); gvkErr == nil {
+		mutation.APIVersion, mutation.Kind = gvk.GroupVersion().String(), gvk.Kind
+	}
+	r.add(mutation)
+	return nil
+}
+
+// add appends a mutation unless maxMutations have been recorded.
+func (r *mutationRecorder) add(mutation scrv1.Mutation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.mutations) < maxMutations {
+		r.mutations = append(r.mutations, mutation)
+	}
+}
+
+// recorded returns the mutations recorded so far.
+func (r *mutationRecorder) recorded() []scrv1.Mutation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]scrv1.Mutation(nil), r.mutations...)
+}
+
+// Create implements client.Client.
+func (r *mutationRecorder) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := r.Client.Create(ctx, obj, opts...)
+	return r.record(err, "create", obj, obj.GetNamespace(), obj.GetName(), "")
+}
+
+// Update implements client.Client.
+func (r *mutationRecorder) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	err := r.Client.Update(ctx, obj, opts...)
+	return r.record(err, "update", obj, obj.GetNamespace(), obj.GetName(), "")
+}
+
+// Patch implements client.Client.
+func (r *mutationRecorder) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	err := r.Client.Patch(ctx, obj, patch, opts...)
+	return r.record(err, "patch", obj, obj.GetNamespace(), obj.GetName(), "")
+}
+
+// Delete implements client.Client.
+func (r *mutationRecorder) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	err := r.Client.Delete(ctx, obj, opts...)
+	return r.record(err, "delete", obj, obj.GetNamespace(), obj.GetName(), "")
+}
+
+// DeleteAllOf implements client.Client.
+func (r *mutationRecorder) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	err := r.Client.DeleteAllOf(ctx, obj, opts...)
+	options := &client.DeleteAllOfOptions{}
+	options.ApplyOptions(opts)
+	return r.record(err, "deletecollection", obj, options.Namespace, "", "")
+}
+
+// Status implements client.StatusClient.
+func (r *mutationRecorder) Status() client.SubResourceWriter {
+	return r.SubResource("status")
+}
+
+// SubResource implements client.SubResourceClientConstructor.
+func (r *mutationRecorder) SubResource(subresource string) client.SubResourceClient {
+	return &subResourceRecorder{SubResourceClient: r.Client.SubResource(subresource), recorder: r, subresource: subresource}
+}
+
+// subResourceRecorder records the changes of subresources made through a mutationRecorder.
+type subResourceRecorder struct {
+	client.SubResourceClient
+	recorder    *mutationRecorder
+	subresource string
+}
+
+// Create implements client.SubResourceWriter.
+func (s *subResourceRecorder) Create(ctx context.Context, obj, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	err := s.SubResourceClient.Create(ctx, obj, subResource, opts...)
+	return s.recorder.record(err, "create", obj, obj.GetNamespace(), obj.GetName(), s.subresource)
+}
+
+// Update implements client.SubResourceWriter.
+func (s *subResourceRecorder) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	err := s.SubResourceClient.Update(ctx, obj, opts...)
+	return s.recorder.record(err, "update", obj, obj.GetNamespace(), obj.GetName(), s.subresource)
+}
+
+// Patch implements client.SubResourceWriter.
+func (s *subResourceRecorder) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	err := s.SubResourceClient.Patch(ctx, obj, patch, opts...)
+	return s.recorder.record(err, "patch", obj, obj.GetNamespace(), obj.GetName(), s.subresource)
+}
+
+// dynamicRecorder is the dynamic client of dry-run executions. Like mutationRecorder,
+// it submits all changes with dryRun=All and records those admitted by the API server.
+type dynamicRecorder struct {
+	dynamic.Interface
+	recorder *mutationRecorder
+}
+
+// Resource implements dynamic.Interface.
+func (d *dynamicRecorder) Resource(resource schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	res := d.Interface.Resource(resource)
+	return &resourceRecorder{ResourceInterface: res, namespaceable: res, recorder: d.recorder, resource: resource}
+}
+
+// resourceRecorder records the changes of a resource made through a dynamicRecorder.
+type resourceRecorder struct {
+	dynamic.ResourceInterface
+	// nil once restricted to a namespace
+	namespaceable dynamic.NamespaceableResourceInterface
+	recorder      *mutationRecorder
+	resource      schema.GroupVersionResource
+	namespace     string
+}
+
+// Namespace implements dynamic.NamespaceableResourceInterface.
+func (r *resourceRecorder) Namespace(namespace string) dynamic.ResourceInterface {
+	return &resourceRecorder{
+		ResourceInterface: r.namespaceable.Namespace(namespace),
+		recorder:          r.recorder,
+		resource:          r.resource,
+		namespace:         namespace,
+	}
+}
+
+// Create implements dynamic.ResourceInterface.
+func (r *resourceRecorder) Create(ctx context.Context, obj *unstructured.Unstructured, options metav1.CreateOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	options.DryRun = []string{metav1.DryRunAll}
+	created, err := r.ResourceInterface.Create(ctx, obj, options, subresources...)
+	return created, r.recorder.recordResource(err, "create", r.resource, obj, r.namespace, obj.GetName(), strings.Join(subresources, "/"))
+}
+
+// Update implements dynamic.ResourceInterface.
+func (r *resourceRecorder) Update(ctx context.Context, obj *unstructured.Unstructured, options metav1.UpdateOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	options.DryRun = []string{metav1.DryRunAll}
+	updated, err := r.ResourceInterface.Update(ctx, obj, options, subresources...)
+	return updated, r.recorder.recordResource(err, "update", r.resource, obj, r.namespace, obj.GetName(), strings.Join(subresources, "/"))
+}
+
+// UpdateStatus implements dynamic.ResourceInterface.
+func (r *resourceRecorder) UpdateStatus(ctx context.Context, obj *unstructured.Unstructured, options metav1.UpdateOptions) (*unstructured.Unstructured, error) {
+	options.DryRun = []string{metav1.DryRunAll}
+	updated, err := r.ResourceInterface.UpdateStatus(ctx, obj, options)
+	return updated, r.recorder.recordResource(err, "update", r.resource, obj, r.namespace, obj.GetName(), "status")
+}
+
+// Patch implements dynamic.ResourceInterface.
+func (r *resourceRecorder) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, options metav1.PatchOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	options.DryRun = []string{metav1.DryRunAll}
+	patched, err := r.ResourceInterface.Patch(ctx, name, pt, data, options, subresources...)
+	return patched, r.recorder.recordResource(err, "patch", r.resource, patched, r.namespace, name, strings.Join(subresources, "/"))
+}
+
+// Apply implements dynamic.ResourceInterface.
+func (r *resourceRecorder) Apply(ctx context.Context, name string, obj *unstructured.Unstructured, options metav1.ApplyOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	options.DryRun = []string{metav1.DryRunAll}
+	applied, err := r.ResourceInterface.Apply(ctx, name, obj, options, subresources...)
+	return applied, r.recorder.recordResource(err, "patch", r.resource, obj, r.namespace, name, strings.Join(subresources, "/"))
+}
+
+// ApplyStatus implements dynamic.ResourceInterface.
+func (r *resourceRecorder) ApplyStatus(ctx context.Context, name string, obj *unstructured.Unstructured, options metav1.ApplyOptions) (*unstructured.Unstructured, error) {
+	options.DryRun = []string{metav1.DryRunAll}
+	applied, err := r.ResourceInterface.ApplyStatus(ctx, name, obj, options)
+	return applied, r.recorder.recordResource(err, "patch", r.resource, obj, r.namespace, name, "status")
+}
+
+// Delete implements dynamic.ResourceInterface.
+func (r *resourceRecorder) Delete(ctx context.Context, name string, options metav1.DeleteOptions, subresources ...string) error {
+	options.DryRun = []string{metav1.DryRunAll}
+	err := r.ResourceInterface.Delete(ctx, name, options, subresources...)
+	return r.recorder.recordResource(err, "delete", r.resource, nil, r.namespace, name, strings.Join(subresources, "/"))
+}
+
+// DeleteCollection implements dynamic.ResourceInterface.
+func (r *resourceRecorder) DeleteCollection(ctx context.Context, options metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	options.DryRun = []string{metav1.DryRunAll}
+	err := r.ResourceInterface.DeleteCollection(ctx, options, listOptions)
+	return r.recorder.recordResource(err, "deletecollection", r.resource, nil, r.namespace, "", "")
+}
+
+// setDryRun records in the status of a script whether its execution was a dry run
+// and the mutations it would have made.
+func setDryRun(script scriptObject, recorder *mutationRecorder) {
+	status := script.GetScriptStatus()
+	status.DryRun = recorder != nil
+	status.Mutations = nil
+	if recorder != nil {
+		status.Mutations = recorder.recorded()
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+	"github.com/veith4f/scropt/internal/lua"
+)
+
+var _ = Describe("Script dry run", func() {
+	ctx := context.Background()
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}
+
+	It("should record changes instead of persisting them", func() {
+		c := fake.NewClientBuilder().WithObjects(pod).WithStatusSubresource(pod).Build()
+		recorder := newMutationRecorder(c)
+
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}
+		Expect(recorder.Create(ctx, cm)).To(Succeed())
+		Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(cm), cm))).To(BeTrue())
+
+		existing := &corev1.Pod{}
+		Expect(recorder.Get(ctx, client.ObjectKeyFromObject(pod), existing)).To(Succeed())
+		existing.Status.Phase = corev1.PodFailed
+		Expect(recorder.Status().Update(ctx, existing)).To(Succeed())
+		Expect(recorder.Delete(ctx, existing)).To(Succeed())
+
+		Expect(recorder.recorded()).To(Equal([]scriptsv1.Mutation{
+			{Verb: "create", APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "example"},
+			{Verb: "update", APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "example", Subresource: "status"},
+			{Verb: "delete", APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "example"},
+		}))
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(pod), existing)).To(Succeed())
+		Expect(existing.Status.Phase).To(BeEmpty())
+	})
+
+	It("should record changes of the dynamic client", func() {
+		recorder := newMutationRecorder(fake.NewClientBuilder().Build())
+		base := dynamicfake.NewSimpleDynamicClient(clientgoscheme.Scheme)
+		d := &dynamicRecorder{Interface: base, recorder: recorder}
+		deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("apps/v1")
+		obj.SetKind("Deployment")
+		obj.SetName("example")
+		_, err := d.Resource(deployments).Namespace("default").Create(ctx, obj, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d.Resource(deployments).Namespace("default").Delete(ctx, "missing", metav1.DeleteOptions{})).NotTo(Succeed())
+
+		Expect(base.Actions()).To(HaveLen(2))
+		Expect(recorder.recorded()).To(Equal([]scriptsv1.Mutation{
+			{Verb: "create", APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "example"},
+		}))
+	})
+
+	It("should expose the client package to scripts executed as dry run", func() {
+		c := fake.NewClientBuilder().WithObjects(pod).Build()
+		script := &scriptsv1.LuaScript{}
+		script.Spec.DryRun = true
+		clients, err := (&runner{}).clients(ctx, c, script)
+		Expect(err).NotTo(HaveOccurred())
+
+		code := `
+			local key = client.ObjectKey:new({Name = "example", Namespace = "default"})
+			local pod = core.Pod:new()
+			client.Get(ctx, key, pod)
+			client.Delete(ctx, pod)
+			return pod:get().ObjectMeta.Name`
+		// executions are passed cancellable contexts
+		execCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		result, err := runCode(execCtx, c, clients, &runner{}, script, "LuaScript", compileLua, code,
+			lua.WithDiscoveryClient(kubefake.NewSimpleClientset().Discovery()),
+			lua.WithDynamicClient(dynamicfake.NewSimpleDynamicClient(clientgoscheme.Scheme), c.RESTMapper()))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(result)).To(Equal(`"example"`))
+		Expect(clients.recorder.recorded()).To(Equal([]scriptsv1.Mutation{
+			{Verb: "delete", APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "example"},
+		}))
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(pod), &corev1.Pod{})).To(Succeed())
+	})
+
+	It("should execute scripts as dry run if requested by spec or operator", func() {
+		c := fake.NewClientBuilder().Build()
+		script := &scriptsv1.LuaScript{}
+
+		clients, err := (&runner{}).clients(ctx, c, script)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clients.recorder).To(BeNil())
+		clients, err = (&runner{dryRun: true}).clients(ctx, c, script)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clients.recorder).NotTo(BeNil())
+		Expect(clients.client).To(BeIdenticalTo(clients.recorder))
+		script.Spec.DryRun = true
+		clients, err = (&runner{}).clients(ctx, c, script)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clients.recorder).NotTo(BeNil())
+
+		setDryRun(script, clients.recorder)
+		Expect(script.Status.DryRun).To(BeTrue())
+		Expect(script.Status.Mutations).To(BeEmpty())
+	})
+})
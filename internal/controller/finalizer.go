@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scrv1 "github.com/veith4f/scropt/api/v1"
+	lua "github.com/veith4f/scropt/internal/lua"
+)
+
+// onDeleteRetryInterval is the time between attempts to execute failed onDelete code.
+const onDeleteRetryInterval = 30 * time.Second
+
+// updateFinalizer adds the onDelete finalizer to scripts with onDelete code and removes it from scripts without.
+func updateFinalizer(ctx context.Context, c client.Client, script scriptObject) error {
+	onDelete := script.GetScriptSpec().OnDelete != ""
+	if onDelete == controllerutil.ContainsFinalizer(script, scrv1.OnDeleteFinalizer) {
+		return nil
+	}
+	patch := client.MergeFromWithOptions(script.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+	if onDelete {
+		controllerutil.AddFinalizer(script, scrv1.OnDeleteFinalizer)
+	} else {
+		controllerutil.RemoveFinalizer(script, scrv1.OnDeleteFinalizer)
+	}
+	return c.Patch(ctx, script, patch)
+}
+
+// removeFinalizer releases a deleted script.
+func removeFinalizer(ctx context.Context, c client.Client, script scriptObject) error {
+	patch := client.MergeFromWithOptions(script.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+	controllerutil.RemoveFinalizer(script, scrv1.OnDeleteFinalizer)
+	return client.IgnoreNotFound(c.Patch(ctx, script, patch))
+}
+
+// finalize cancels the executions of a deleted script and executes its onDelete code
+// in the background. The finalizer is removed once the code succeeded or the script
+// is annotated with scropt.io/skip-on-delete. Failed code is retried every onDeleteRetryInterval.
+func finalize(ctx context.Context, c client.Client, r *runner, script scriptObject, kind string, compile compileFunc) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(script, scrv1.OnDeleteFinalizer) {
+		r.cancel(script, "script deleted")
+		return ctrl.Result{}, nil
+	}
+	// deletion increments the generation, executions of the onDelete code are current
+	r.cancelOutdated(script, "script deleted")
+	if script.GetAnnotations()[scrv1.SkipOnDeleteAnnotation] == "true" {
+		log.Printf("Skipping onDelete of %s: %s", kind, fqn(script))
+		r.cancel(script, "onDelete skipped")
+		return ctrl.Result{}, removeFinalizer(ctx, c, script)
+	}
+	// onDelete code is executed once the script or the operator is resumed
+	if suspended, err := r.suspension(ctx, c, script); err != nil {
+		return ctrl.Result{}, err
+	} else if suspended != nil {
+		log.Printf("Postponing onDelete of suspended %s: %s", kind, fqn(script))
+		r.cancel(script, suspended.message)
+		return ctrl.Result{}, nil
+	}
+	if r.active(script) > 0 {
+		// the runner requeues the script once the executions finished
+		return ctrl.Result{}, nil
+	}
+
+	blocked := meta.FindStatusCondition(script.GetScriptStatus().Conditions, scrv1.ConditionDeletionBlocked)
+	if blocked != nil && blocked.Status == metav1.ConditionTrue && blocked.ObservedGeneration == script.GetGeneration() {
+		if wait := onDeleteRetryInterval - time.Since(blocked.LastTransitionTime.Time); wait > 0 {
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+	}
+
+	patch := client.MergeFrom(script.DeepCopyObject().(client.Object))
+	args, err := resolveArgs(ctx, c, script)
+	var invalidArgs *errInvalidArgs
+	if errors.As(err, &invalidArgs) {
+		log.Printf("Invalid args of deleted %s %s: %v", kind, fqn(script), err)
+		setDeletionBlocked(script, err)
+		return ctrl.Result{RequeueAfter: onDeleteRetryInterval}, patchStatus(ctx, c, script, patch)
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	log.Printf("Running onDelete of %s: %s", kind, fqn(script))
+	meta.SetStatusCondition(&script.GetScriptStatus().Conditions, metav1.Condition{
+		Type:               scrv1.ConditionDeletionBlocked,
+		Status:             metav1.ConditionUnknown,
+		Reason:             scrv1.ReasonFinalizing,
+		Message:            "onDelete code is being executed",
+		ObservedGeneration: script.GetGeneration(),
+	})
+	if err := c.Status().Patch(ctx, script, patch); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	running := script.DeepCopyObject().(scriptObject)
+	r.start(ctx, running, func(ctx context.Context, _ func() bool) {
+		clients, err := r.clients(ctx, c, running)
+		if err == nil {
+			_, err = runCode(ctx, c, clients, r, running, kind, compile, running.GetScriptSpec().OnDelete,
+				lua.WithReadOnlyGlobal("args", args))
+		}
+		ctx = context.WithoutCancel(ctx)
+		if err == nil {
+			log.Printf("onDelete of %s succeeded: %s", kind, fqn(running))
+			if err := removeFinalizer(ctx, c, running); err != nil {
+				log.Printf("Failed removing finalizer of %s: %s: %v", kind, fqn(running), err)
+			}
+			return
+		}
+
+		log.Printf("onDelete of %s failed: %s: %v", kind, fqn(running), err)
+		patch := client.MergeFrom(running.DeepCopyObject().(client.Object))
+		setDeletionBlocked(running, err)
+		if err := c.Status().Patch(ctx, running, patch); err != nil {
+			log.Printf("Failed updating %s status to %v: %s", kind, err, fqn(running))
+		}
+	})
+	return ctrl.Result{}, nil
+}
+
+// setDeletionBlocked records that the onDelete code of a deleted script failed.
+func setDeletionBlocked(script scriptObject, err error) {
+	meta.SetStatusCondition(&script.GetScriptStatus().Conditions, metav1.Condition{
+		Type:   scrv1.ConditionDeletionBlocked,
+		Status: metav1.ConditionTrue,
+		Reason: scrv1.ReasonOnDeleteFailed,
+		Message: fmt.Sprintf("%s; annotate with %s=true to delete the script anyway",
+			toScriptError(err), scrv1.SkipOnDeleteAnnotation),
+		ObservedGeneration: script.GetGeneration(),
+	})
+}
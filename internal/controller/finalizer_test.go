@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+)
+
+var _ = Describe("Script finalizer", func() {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(scriptsv1.AddToScheme(scheme)).To(Succeed())
+
+	newScript := func(onDelete string) (client.Client, *scriptsv1.LuaScript) {
+		script := &scriptsv1.LuaScript{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}
+		script.Spec.OnDelete = onDelete
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(script).WithStatusSubresource(script).Build()
+		return c, script
+	}
+
+	It("should hold the finalizer only while onDelete code is set", func() {
+		c, script := newScript(`log("cleanup")`)
+		Expect(updateFinalizer(ctx, c, script)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(script), script)).To(Succeed())
+		Expect(script.Finalizers).To(ConsistOf(scriptsv1.OnDeleteFinalizer))
+
+		script.Spec.OnDelete = ""
+		Expect(updateFinalizer(ctx, c, script)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(script), script)).To(Succeed())
+		Expect(script.Finalizers).To(BeEmpty())
+	})
+
+	It("should release deleted scripts annotated to skip onDelete", func() {
+		c, script := newScript(`error("cleanup failed")`)
+		Expect(updateFinalizer(ctx, c, script)).To(Succeed())
+		script.Annotations = map[string]string{scriptsv1.SkipOnDeleteAnnotation: "true"}
+		Expect(c.Update(ctx, script)).To(Succeed())
+		Expect(c.Delete(ctx, script)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(script), script)).To(Succeed())
+
+		_, err := finalize(ctx, c, &runner{}, script, "LuaScript", compileLua)
+		Expect(err).NotTo(HaveOccurred())
+		err = c.Get(ctx, client.ObjectKeyFromObject(script), script)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should wait before retrying failed onDelete code", func() {
+		c, script := newScript(`error("cleanup failed")`)
+		Expect(updateFinalizer(ctx, c, script)).To(Succeed())
+		Expect(c.Delete(ctx, script)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(script), script)).To(Succeed())
+		setDeletionBlocked(script, errors.New("cleanup failed"))
+		Expect(c.Status().Update(ctx, script)).To(Succeed())
+
+		result, err := finalize(ctx, c, &runner{}, script, "LuaScript", compileLua)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(script.Finalizers).To(ConsistOf(scriptsv1.OnDeleteFinalizer))
+	})
+})
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -68,7 +69,8 @@ var _ = Describe("LuaScript Controller", func() {
 		})
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
-			controllerReconciler := &LuaScriptReconciler{
+			controllerReconciler := &ScriptReconciler{
+				Kind:   LuaScriptKind,
 				Client: k8sClient,
 				Scheme: k8sClient.Scheme(),
 			}
@@ -77,8 +79,14 @@ var _ = Describe("LuaScript Controller", func() {
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+
+			By("Reporting a script without code as pending")
+			resource := &scriptsv1.LuaScript{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal(scriptsv1.ScriptPending))
+			Expect(resource.Status.ObservedGeneration).To(Equal(resource.Generation))
+			Expect(meta.IsStatusConditionPresentAndEqual(resource.Status.Conditions,
+				scriptsv1.ConditionSucceeded, metav1.ConditionUnknown)).To(BeTrue())
 		})
 	})
 })
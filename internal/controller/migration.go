@@ -0,0 +1,259 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"log"
+	"maps"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	scrv1 "github.com/veith4f/scropt/api/v1"
+	scrv2 "github.com/veith4f/scropt/api/v2"
+)
+
+// migrationRetryInterval is the interval in which running v1 scripts are checked for completion.
+const migrationRetryInterval = 10 * time.Second
+
+// migration describes how scripts of a v1 kind are migrated to v2 Scripts.
+type migration struct {
+	kind      string
+	language  scrv2.Language
+	newScript func() scriptObject
+}
+
+var migrations = []migration{
+	{kind: "LuaScript", language: scrv2.LanguageLua, newScript: func() scriptObject { return &scrv1.LuaScript{} }},
+	{kind: "MoonScript", language: scrv2.LanguageMoonScript, newScript: func() scriptObject { return &scrv1.MoonScript{} }},
+}
+
+// ScriptMigrationReconciler migrates LuaScripts and MoonScripts to v2 Scripts of the same
+// name. The v1 script is suspended first, so that the two are never executed concurrently.
+// The Script then takes over spec, status, ScriptRuns and result ConfigMap of the v1 script,
+// which is deleted without running its onDelete code.
+type ScriptMigrationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=scripts.scropt.io,resources=luascripts;moonscripts,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=scripts.scropt.io,resources=scripts,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=scripts.scropt.io,resources=scripts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=scripts.scropt.io,resources=scriptruns,verbs=list;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=patch
+
+// migrate migrates the v1 script identified by req.
+func (r *ScriptMigrationReconciler) migrate(ctx context.Context, req ctrl.Request, m migration) (ctrl.Result, error) {
+	old := m.newScript()
+	if err := r.Get(ctx, req.NamespacedName, old); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if old.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+	_, suspending := old.GetAnnotations()[scrv1.MigratingAnnotation]
+	if !suspending && old.GetScriptStatus().Phase == scrv1.ScriptRunning {
+		// the result of the running execution must be carried over
+		return ctrl.Result{RequeueAfter: migrationRetryInterval}, nil
+	}
+
+	script := &scrv2.Script{}
+	err := r.Get(ctx, req.NamespacedName, script)
+	switch {
+	case apierrors.IsNotFound(err):
+		script = nil
+	case err != nil:
+		return ctrl.Result{}, err
+	case script.Annotations[scrv2.MigratedFromAnnotation] != m.kind:
+		log.Printf("Not migrating %s, a Script of the same name exists: %s", m.kind, fqn(old))
+		if suspending {
+			return ctrl.Result{}, r.annotate(ctx, old, scrv1.MigratingAnnotation, "")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// the v1 script must not be executed once the Script exists
+	if !suspending {
+		return ctrl.Result{RequeueAfter: migrationRetryInterval}, r.annotate(ctx, old, scrv1.MigratingAnnotation, "true")
+	}
+	status := old.GetScriptStatus()
+	if !meta.IsStatusConditionTrue(status.Conditions, scrv1.ConditionSuspended) || status.Phase == scrv1.ScriptRunning {
+		return ctrl.Result{RequeueAfter: migrationRetryInterval}, nil
+	}
+
+	if script == nil {
+		script = newMigratedScript(old, m)
+		if err := r.Create(ctx, script); err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Printf("Created Script for %s: %s", m.kind, fqn(old))
+	}
+	if migrating(script) {
+		script.Status.ScriptStatus = migratedStatus(old, script)
+		if err := r.Status().Update(ctx, script); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if err := r.adoptRuns(ctx, old, script); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.adoptResult(ctx, old, script); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// the script lives on as Script, its onDelete code must not run
+	if err := r.annotate(ctx, old, scrv1.SkipOnDeleteAnnotation, "true"); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if err := r.Delete(ctx, old); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log.Printf("Migrated %s to Script: %s", m.kind, fqn(old))
+	return ctrl.Result{}, nil
+}
+
+// annotate sets the annotation key of a v1 script to value, or removes it if value is empty.
+func (r *ScriptMigrationReconciler) annotate(ctx context.Context, old scriptObject, key, value string) error {
+	patch := client.MergeFrom(old.DeepCopyObject().(client.Object))
+	annotations := old.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	if value == "" {
+		delete(annotations, key)
+	} else {
+		annotations[key] = value
+	}
+	old.SetAnnotations(annotations)
+	return r.Patch(ctx, old, patch)
+}
+
+// newMigratedScript returns the Script replacing a v1 script.
+func newMigratedScript(old scriptObject, m migration) *scrv2.Script {
+	annotations := maps.Clone(old.GetAnnotations())
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	delete(annotations, corev1.LastAppliedConfigAnnotation)
+	delete(annotations, scrv1.MigratingAnnotation)
+	annotations[scrv2.MigratedFromAnnotation] = m.kind
+
+	script := &scrv2.Script{ObjectMeta: metav1.ObjectMeta{
+		Name:        old.GetName(),
+		Namespace:   old.GetNamespace(),
+		Labels:      maps.Clone(old.GetLabels()),
+		Annotations: annotations,
+	}}
+	script.Spec.Language = m.language
+	script.Spec.ScriptSpec = *old.GetScriptSpec().DeepCopy()
+	return script
+}
+
+// migratedStatus returns the status of a v1 script for the Script replacing it,
+// so that the Script is only executed when the v1 script would have been.
+func migratedStatus(old scriptObject, script *scrv2.Script) scrv1.ScriptStatus {
+	status := *old.GetScriptStatus().DeepCopy()
+	// the v1 script was only suspended for the migration
+	if cond := meta.FindStatusCondition(status.Conditions, scrv1.ConditionSuspended); cond != nil && cond.Reason == scrv1.ReasonMigrating {
+		meta.RemoveStatusCondition(&status.Conditions, scrv1.ConditionSuspended)
+		status.Phase = completedPhase(&status)
+	}
+	if status.Phase == "" {
+		status.Phase = scrv1.ScriptPending
+	}
+	if status.ObservedGeneration == old.GetGeneration() {
+		status.ObservedGeneration = script.Generation
+	} else {
+		status.ObservedGeneration = 0
+	}
+	return status
+}
+
+// migrating returns true if script has been created for a v1 script whose status is yet to be copied.
+func migrating(script *scrv2.Script) bool {
+	_, migrated := script.Annotations[scrv2.MigratedFromAnnotation]
+	return migrated && script.Status.Phase == ""
+}
+
+// adoptRuns moves the ScriptRuns of a v1 script to script, so that the history
+// of its executions is not garbage collected along with the v1 script.
+func (r *ScriptMigrationReconciler) adoptRuns(ctx context.Context, old scriptObject, script *scrv2.Script) error {
+	runs := &scrv1.ScriptRunList{}
+	if err := r.List(ctx, runs, client.InNamespace(old.GetNamespace()),
+		client.MatchingLabels{scrv1.ScriptRunScriptUIDLabel: string(old.GetUID())}); err != nil {
+		return err
+	}
+	for i := range runs.Items {
+		run := &runs.Items[i]
+		patch := client.MergeFrom(run.DeepCopy())
+		if err := controllerutil.RemoveOwnerReference(old, run, r.Scheme); err != nil {
+			return err
+		}
+		if err := controllerutil.SetOwnerReference(script, run, r.Scheme); err != nil {
+			return err
+		}
+		run.Labels[scrv1.ScriptRunScriptUIDLabel] = string(script.UID)
+		if err := r.Patch(ctx, run, patch); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// adoptResult makes script an owner of the result ConfigMap of a v1 script,
+// so that it is not garbage collected along with the v1 script.
+func (r *ScriptMigrationReconciler) adoptResult(ctx context.Context, old scriptObject, script *scrv2.Script) error {
+	name := old.GetScriptStatus().ResultConfigMap
+	if name == "" {
+		return nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: old.GetNamespace(), Name: name}, cm); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	patch := client.MergeFrom(cm.DeepCopy())
+	if err := controllerutil.SetOwnerReference(script, cm, r.Scheme); err != nil {
+		return err
+	}
+	return r.Patch(ctx, cm, patch)
+}
+
+// SetupWithManager sets up a controller per migrated kind with the Manager.
+func (r *ScriptMigrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	for _, m := range migrations {
+		if err := ctrl.NewControllerManagedBy(mgr).
+			For(m.newScript()).
+			Named(strings.ToLower(m.kind) + "-migration").
+			Complete(reconcile.Func(func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+				return r.migrate(ctx, req, m)
+			})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
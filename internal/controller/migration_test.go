@@ -0,0 +1,180 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+	scriptsv2 "github.com/veith4f/scropt/api/v2"
+)
+
+var _ = Describe("Script migration", func() {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(scriptsv1.AddToScheme(scheme)).To(Succeed())
+	Expect(scriptsv2.AddToScheme(scheme)).To(Succeed())
+
+	key := client.ObjectKey{Namespace: "default", Name: "example"}
+	req := ctrl.Request{NamespacedName: key}
+
+	newReconciler := func(objs ...client.Object) *ScriptMigrationReconciler {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+			WithStatusSubresource(&scriptsv1.MoonScript{}, &scriptsv2.Script{}).Build()
+		return &ScriptMigrationReconciler{Client: c, Scheme: scheme}
+	}
+
+	newMoonScript := func(phase scriptsv1.ScriptPhase) *scriptsv1.MoonScript {
+		script := &scriptsv1.MoonScript{ObjectMeta: metav1.ObjectMeta{
+			Name:        key.Name,
+			Namespace:   key.Namespace,
+			UID:         "v1",
+			Annotations: map[string]string{corev1.LastAppliedConfigAnnotation: "{}", "team": "ops"},
+		}}
+		script.Spec.Code = `log "hello"`
+		setRunning(script, scriptCode{code: script.Spec.Code})
+		if phase != scriptsv1.ScriptRunning {
+			setCompleted(script, nil)
+		}
+		script.Status.ResultConfigMap = "example-result"
+		return script
+	}
+
+	// reconcileV1 reconciles the v1 script like its controller
+	reconcileV1 := func(r *ScriptMigrationReconciler, run *runner) {
+		GinkgoHelper()
+		old := &scriptsv1.MoonScript{}
+		Expect(r.Get(ctx, key, old)).To(Succeed())
+		_, err := reconcileScript(ctx, r.Client, run, &triggers{}, old, "MoonScript", compileLua)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	// migrateSuspended migrates the v1 script, which is suspended by its controller first
+	migrateSuspended := func(r *ScriptMigrationReconciler) {
+		GinkgoHelper()
+		result, err := r.migrate(ctx, req, migrations[1])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(migrationRetryInterval))
+		reconcileV1(r, &runner{})
+		Expect(r.migrate(ctx, req, migrations[1])).To(Equal(ctrl.Result{}))
+	}
+
+	It("should replace v1 scripts with Scripts", func() {
+		old := newMoonScript(scriptsv1.ScriptSucceeded)
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "example-result", Namespace: "default"}}
+		Expect(controllerutil.SetOwnerReference(old, cm, scheme)).To(Succeed())
+		run := &scriptsv1.ScriptRun{ObjectMeta: metav1.ObjectMeta{
+			Name:      "example-1",
+			Namespace: "default",
+			Labels:    map[string]string{scriptsv1.ScriptRunScriptUIDLabel: "v1"},
+		}}
+		Expect(controllerutil.SetOwnerReference(old, run, scheme)).To(Succeed())
+		r := newReconciler(old, cm, run)
+
+		migrateSuspended(r)
+
+		script := &scriptsv2.Script{}
+		Expect(r.Get(ctx, key, script)).To(Succeed())
+		Expect(script.Spec.Language).To(Equal(scriptsv2.LanguageMoonScript))
+		Expect(script.Spec.Code).To(Equal(`log "hello"`))
+		Expect(script.Annotations).To(Equal(map[string]string{"team": "ops", scriptsv2.MigratedFromAnnotation: "MoonScript"}))
+		Expect(script.Status.Phase).To(Equal(scriptsv1.ScriptSucceeded))
+		Expect(meta.FindStatusCondition(script.Status.Conditions, scriptsv1.ConditionSuspended)).To(BeNil())
+		Expect(migrating(script)).To(BeFalse())
+
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cm), cm)).To(Succeed())
+		Expect(cm.OwnerReferences).To(HaveLen(2))
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(run), run)).To(Succeed())
+		Expect(run.OwnerReferences).To(ConsistOf(HaveField("UID", script.UID)))
+		Expect(run.Labels).To(HaveKeyWithValue(scriptsv1.ScriptRunScriptUIDLabel, string(script.UID)))
+		Expect(apierrors.IsNotFound(r.Get(ctx, key, &scriptsv1.MoonScript{}))).To(BeTrue())
+	})
+
+	It("should suspend v1 scripts before creating Scripts", func() {
+		old := newMoonScript(scriptsv1.ScriptSucceeded)
+		old.Spec.RunPolicy = scriptsv1.RunAlways
+		r := newReconciler(old)
+
+		Expect(r.migrate(ctx, req, migrations[1])).To(Equal(ctrl.Result{RequeueAfter: migrationRetryInterval}))
+		Expect(r.Get(ctx, key, old)).To(Succeed())
+		Expect(old.Annotations).To(HaveKey(scriptsv1.MigratingAnnotation))
+
+		// the v1 controller has not suspended the script yet
+		Expect(r.migrate(ctx, req, migrations[1])).To(Equal(ctrl.Result{RequeueAfter: migrationRetryInterval}))
+		Expect(apierrors.IsNotFound(r.Get(ctx, key, &scriptsv2.Script{}))).To(BeTrue())
+
+		// the v1 controller does not execute the script anymore, not even if it is always executed
+		run := &runner{}
+		reconcileV1(r, run)
+		Expect(run.active(old)).To(BeZero())
+		Expect(r.Get(ctx, key, old)).To(Succeed())
+		Expect(old.Status.Phase).To(Equal(scriptsv1.ScriptSuspended))
+		runs := &scriptsv1.ScriptRunList{}
+		Expect(r.List(ctx, runs)).To(Succeed())
+		Expect(runs.Items).To(BeEmpty())
+
+		Expect(r.migrate(ctx, req, migrations[1])).To(Equal(ctrl.Result{}))
+		script := &scriptsv2.Script{}
+		Expect(r.Get(ctx, key, script)).To(Succeed())
+		Expect(script.Annotations).NotTo(HaveKey(scriptsv1.MigratingAnnotation))
+		Expect(script.Status.Phase).To(Equal(scriptsv1.ScriptSucceeded))
+	})
+
+	It("should wait for running executions", func() {
+		r := newReconciler(newMoonScript(scriptsv1.ScriptRunning))
+		result, err := r.migrate(ctx, req, migrations[1])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(migrationRetryInterval))
+		Expect(apierrors.IsNotFound(r.Get(ctx, key, &scriptsv2.Script{}))).To(BeTrue())
+		old := &scriptsv1.MoonScript{}
+		Expect(r.Get(ctx, key, old)).To(Succeed())
+		Expect(old.Annotations).NotTo(HaveKey(scriptsv1.MigratingAnnotation))
+	})
+
+	It("should not replace Scripts it did not create", func() {
+		existing := &scriptsv2.Script{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+		r := newReconciler(newMoonScript(scriptsv1.ScriptSucceeded), existing)
+		Expect(r.migrate(ctx, req, migrations[1])).To(Equal(ctrl.Result{}))
+		old := &scriptsv1.MoonScript{}
+		Expect(r.Get(ctx, key, old)).To(Succeed())
+		Expect(old.Annotations).NotTo(HaveKey(scriptsv1.MigratingAnnotation))
+	})
+
+	It("should compile code by language", func() {
+		code, err := compilerFor(scriptsv2.LanguageLua)(`x = 1`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(code).To(Equal("x = 1"))
+		_, err = compilerFor("python")(`x = 1`)
+		Expect(err).To(MatchError(ContainSubstring("unsupported language")))
+	})
+})
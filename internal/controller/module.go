@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scrv1 "github.com/veith4f/scropt/api/v1"
+	lua "github.com/veith4f/scropt/internal/lua"
+)
+
+// moduleLoader resolves modules required by scripts in namespace to the code of
+// the LuaModule of the same name in namespace or otherwise the ClusterLuaModule.
+func moduleLoader(ctx context.Context, c client.Client, namespace string) lua.ModuleLoader {
+	return func(name string) (string, bool, error) {
+		spec, err := getModule(ctx, c, namespace, name)
+		if err != nil || spec == nil {
+			return "", false, err
+		}
+		if spec.Language == scrv1.ModuleMoonScript {
+			code, err := lua.CompileMoonscript(spec.Code)
+			return code, true, err
+		}
+		return spec.Code, true, nil
+	}
+}
+
+// getModule returns the spec of the module of the given name visible in namespace or nil if there is none.
+func getModule(ctx context.Context, c client.Client, namespace, name string) (*scrv1.LuaModuleSpec, error) {
+	if namespace != "" {
+		module := &scrv1.LuaModule{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, module)
+		if err == nil {
+			return &module.Spec, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	module := &scrv1.ClusterLuaModule{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, module); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return &module.Spec, nil
+}
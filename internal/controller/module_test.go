@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+)
+
+var _ = Describe("Script module", func() {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	Expect(scriptsv1.AddToScheme(scheme)).To(Succeed())
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&scriptsv1.LuaModule{
+			ObjectMeta: metav1.ObjectMeta{Name: "team.helpers", Namespace: "default"},
+			Spec:       scriptsv1.LuaModuleSpec{Code: `return "namespaced"`},
+		},
+		&scriptsv1.ClusterLuaModule{
+			ObjectMeta: metav1.ObjectMeta{Name: "team.helpers"},
+			Spec:       scriptsv1.LuaModuleSpec{Code: `return "cluster"`},
+		},
+	).Build()
+
+	It("should prefer modules in the namespace of the script", func() {
+		code, found, err := moduleLoader(ctx, c, "default")("team.helpers")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(code).To(Equal(`return "namespaced"`))
+	})
+
+	It("should fall back to cluster modules", func() {
+		code, found, err := moduleLoader(ctx, c, "other")("team.helpers")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(code).To(Equal(`return "cluster"`))
+	})
+
+	It("should not find unknown modules", func() {
+		_, found, err := moduleLoader(ctx, c, "default")("team.unknown")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+})
@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	openapi "k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// resultKey is the key of the result ConfigMap the result is written to
+	resultKey = "result.json"
+	// defaultResultSizeThreshold is the size above which results are written to the result ConfigMap
+	defaultResultSizeThreshold = 4096
+	// maxStatusResultSize is the size above which results are not stored in status
+	maxStatusResultSize = 64 * 1024
+)
+
+// errInvalidResult is returned by recordResult if the result of a script does
+// not conform to its result schema or cannot be stored.
+type errInvalidResult struct {
+	msg string
+}
+
+func (e *errInvalidResult) Error() string {
+	return e.msg
+}
+
+// recordResult validates the result of an execution and stores it in the status
+// of the script or, if it exceeds the size threshold, in the result ConfigMap.
+func recordResult(ctx context.Context, c client.Client, script scriptObject, result json.RawMessage) error {
+	spec := script.GetScriptSpec()
+	status := script.GetScriptStatus()
+
+	if spec.ResultSchema != nil {
+		schema := &openapi.Schema{}
+		if err := json.Unmarshal(spec.ResultSchema.Raw, schema); err != nil {
+			return &errInvalidResult{msg: fmt.Sprintf("invalid resultSchema: %v", err)}
+		}
+		var value any
+		if result != nil {
+			if err := json.Unmarshal(result, &value); err != nil {
+				return err
+			}
+		}
+		if err := validate.AgainstSchema(schema, value, strfmt.Default); err != nil {
+			return &errInvalidResult{msg: fmt.Sprintf("result does not match resultSchema: %v", err)}
+		}
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if ref := spec.ResultConfigMap; ref != nil {
+		threshold := int32(defaultResultSizeThreshold)
+		if ref.SizeThreshold != nil {
+			threshold = *ref.SizeThreshold
+		}
+		if len(result) > int(threshold) {
+			if err := writeResultConfigMap(ctx, c, script, ref.Name, result); err != nil {
+				return fmt.Errorf("failed writing result to ConfigMap %s: %w", ref.Name, err)
+			}
+			status.ResultConfigMap = ref.Name
+			return nil
+		}
+	}
+
+	if len(result) > maxStatusResultSize {
+		return &errInvalidResult{msg: fmt.Sprintf("result of %d bytes exceeds %d bytes, set resultConfigMap", len(result), maxStatusResultSize)}
+	}
+	status.Result = &apiextensionsv1.JSON{Raw: result}
+	return nil
+}
+
+// writeResultConfigMap creates the result ConfigMap of a script, owned by the script,
+// or updates it if the script already owns it. ConfigMaps not owned by the script
+// are left untouched, so that scripts cannot overwrite ConfigMaps of others.
+func writeResultConfigMap(ctx context.Context, c client.Client, script scriptObject, name string, result json.RawMessage) error {
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: referenceNamespace(script), Name: name}
+	if err := c.Get(ctx, key, cm); apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: key.Namespace},
+			Data:       map[string]string{resultKey: string(result)},
+		}
+		if err := controllerutil.SetOwnerReference(script, cm, c.Scheme()); err != nil {
+			return err
+		}
+		return c.Create(ctx, cm)
+	} else if err != nil {
+		return err
+	}
+
+	if !ownedBy(cm, script) {
+		return &errInvalidResult{msg: "ConfigMap exists and is not owned by the script"}
+	}
+	patch := client.MergeFrom(cm.DeepCopy())
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[resultKey] = string(result)
+	return c.Patch(ctx, cm, patch)
+}
+
+// ownedBy returns true if obj has an owner reference to owner.
+func ownedBy(obj, owner metav1.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+	return false
+}
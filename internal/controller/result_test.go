@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+)
+
+var _ = Describe("Script result", func() {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(scriptsv1.AddToScheme(scheme)).To(Succeed())
+
+	newScript := func() *scriptsv1.LuaScript {
+		return &scriptsv1.LuaScript{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default", UID: "1234"}}
+	}
+
+	It("should store results in status", func() {
+		script := newScript()
+		Expect(recordResult(ctx, fake.NewClientBuilder().WithScheme(scheme).Build(), script, json.RawMessage(`{"ok":true}`))).To(Succeed())
+		Expect(string(script.Status.Result.Raw)).To(Equal(`{"ok":true}`))
+	})
+
+	It("should reject results not matching the schema", func() {
+		script := newScript()
+		script.Spec.ResultSchema = &apiextensionsv1.JSON{Raw: []byte(`{"type": "object", "required": ["ok"]}`)}
+		err := recordResult(ctx, fake.NewClientBuilder().WithScheme(scheme).Build(), script, json.RawMessage(`"done"`))
+		var invalid *errInvalidResult
+		Expect(errors.As(err, &invalid)).To(BeTrue())
+		Expect(script.Status.Result).To(BeNil())
+	})
+
+	It("should write results exceeding the threshold to the ConfigMap", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		script := newScript()
+		script.Spec.ResultConfigMap = &scriptsv1.ResultConfigMapSpec{Name: "example-result", SizeThreshold: ptr.To[int32](16)}
+		result := json.RawMessage(`"` + strings.Repeat("x", 32) + `"`)
+		Expect(recordResult(ctx, c, script, result)).To(Succeed())
+		Expect(script.Status.Result).To(BeNil())
+		Expect(script.Status.ResultConfigMap).To(Equal("example-result"))
+
+		cm := &corev1.ConfigMap{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "example-result"}, cm)).To(Succeed())
+		Expect(cm.Data).To(HaveKeyWithValue(resultKey, string(result)))
+		Expect(cm.OwnerReferences).To(HaveLen(1))
+	})
+
+	It("should not overwrite ConfigMaps it does not own", func() {
+		existing := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "example-result", Namespace: "default"},
+			Data:       map[string]string{resultKey: "user data"},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+		script := newScript()
+		script.Spec.ResultConfigMap = &scriptsv1.ResultConfigMapSpec{Name: "example-result", SizeThreshold: ptr.To[int32](16)}
+		err := recordResult(ctx, c, script, json.RawMessage(`"`+strings.Repeat("x", 32)+`"`))
+		var invalid *errInvalidResult
+		Expect(errors.As(err, &invalid)).To(BeTrue())
+		Expect(script.Status.ResultConfigMap).To(BeEmpty())
+
+		setCompleted(script, err)
+		Expect(script.Status.Phase).To(Equal(scriptsv1.ScriptFailed))
+
+		cm := &corev1.ConfigMap{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "example-result"}, cm)).To(Succeed())
+		Expect(cm.Data).To(HaveKeyWithValue(resultKey, "user data"))
+		Expect(cm.OwnerReferences).To(BeEmpty())
+	})
+
+	It("should update ConfigMaps owned by the script", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		script := newScript()
+		script.Spec.ResultConfigMap = &scriptsv1.ResultConfigMapSpec{Name: "example-result", SizeThreshold: ptr.To[int32](16)}
+		Expect(recordResult(ctx, c, script, json.RawMessage(`"`+strings.Repeat("x", 32)+`"`))).To(Succeed())
+		result := json.RawMessage(`"` + strings.Repeat("y", 32) + `"`)
+		Expect(recordResult(ctx, c, script, result)).To(Succeed())
+
+		cm := &corev1.ConfigMap{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "example-result"}, cm)).To(Succeed())
+		Expect(cm.Data).To(HaveKeyWithValue(resultKey, string(result)))
+		Expect(cm.OwnerReferences).To(HaveLen(1))
+	})
+})
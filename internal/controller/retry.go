@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+
+	scrv1 "github.com/veith4f/scropt/api/v1"
+	lua "github.com/veith4f/scropt/internal/lua"
+)
+
+// Retry policy of scripts whose spec.retryPolicy leaves fields unset.
+const (
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = 10 * time.Second
+	defaultMaxBackoff     = 5 * time.Minute
+)
+
+// defaultRetryOn are the error classes retried by retry policies without retryOn.
+var defaultRetryOn = []scrv1.RetryableError{
+	scrv1.RetryOnConflict,
+	scrv1.RetryOnThrottled,
+	scrv1.RetryOnServerError,
+	scrv1.RetryOnTimeout,
+}
+
+// errorClass returns the class of an error a failed execution may be retried for.
+// Errors that are never retried, such as compile errors, exceeded limits, invalid
+// results and cancellations, have no class.
+func errorClass(err error) (scrv1.RetryableError, bool) {
+	var scriptErr *lua.ScriptError
+	var resultErr *errInvalidResult
+	var cancelled *errCancelled
+	var limitErr *lua.LimitError
+	var apiStatus apierrors.APIStatus
+	switch {
+	case err == nil, errors.As(err, &resultErr), errors.As(err, &cancelled), errors.As(err, &limitErr):
+		return "", false
+	case errors.As(err, &scriptErr) && scriptErr.Compile:
+		return "", false
+	case errors.Is(err, errTimedOut), apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		return scrv1.RetryOnTimeout, true
+	case apierrors.IsConflict(err):
+		return scrv1.RetryOnConflict, true
+	case apierrors.IsTooManyRequests(err):
+		return scrv1.RetryOnThrottled, true
+	case errors.As(err, &apiStatus) && apiStatus.Status().Code >= http.StatusInternalServerError,
+		utilnet.IsConnectionRefused(err), utilnet.IsConnectionReset(err), utilnet.IsProbableEOF(err):
+		return scrv1.RetryOnServerError, true
+	case errors.As(err, &scriptErr):
+		return scrv1.RetryOnScriptError, true
+	}
+	return "", false
+}
+
+// maxRetries returns the maximum number of retries of a retry policy.
+func maxRetries(policy *scrv1.RetryPolicy) int32 {
+	if policy.MaxRetries == nil {
+		return defaultMaxRetries
+	}
+	return *policy.MaxRetries
+}
+
+// backoff returns the delay before retrying an execution that failed after attempts attempts.
+func backoff(policy *scrv1.RetryPolicy, attempts int32) time.Duration {
+	delay, limit := defaultInitialBackoff, defaultMaxBackoff
+	if policy.InitialBackoff != nil {
+		delay = policy.InitialBackoff.Duration
+	}
+	if policy.MaxBackoff != nil {
+		limit = policy.MaxBackoff.Duration
+	}
+	for i := int32(1); i < attempts && delay < limit; i++ {
+		delay *= 2
+	}
+	return min(delay, limit)
+}
+
+// setRetry schedules the retry of an execution that completed with err, as recorded
+// by setCompleted, if the retry policy of the script allows for another attempt.
+func setRetry(script scriptObject, err error) {
+	status := script.GetScriptStatus()
+	status.NextRetryTime = nil
+
+	policy := script.GetScriptSpec().RetryPolicy
+	if err == nil || policy == nil || status.Attempts > maxRetries(policy) {
+		return
+	}
+	class, ok := errorClass(err)
+	retryOn := policy.RetryOn
+	if retryOn == nil {
+		retryOn = defaultRetryOn
+	}
+	if !ok || !slices.Contains(retryOn, class) {
+		return
+	}
+	status.NextRetryTime = &metav1.Time{Time: status.CompletionTime.Add(backoff(policy, status.Attempts))}
+}
+
+// retryReason is the reason of the retry of the last execution of a script.
+func retryReason(script scriptObject) string {
+	return fmt.Sprintf("retry %d of %d", script.GetScriptStatus().Attempts,
+		maxRetries(script.GetScriptSpec().RetryPolicy))
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+	"github.com/veith4f/scropt/internal/lua"
+)
+
+var _ = Describe("Script retry", func() {
+	pods := schema.GroupResource{Resource: "pods"}
+
+	newScript := func(policy *scriptsv1.RetryPolicy) *scriptsv1.LuaScript {
+		script := &scriptsv1.LuaScript{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}
+		script.Spec.RetryPolicy = policy
+		return script
+	}
+
+	// fail executes script and completes the execution with err
+	fail := func(script scriptObject, err error) {
+		setRunning(script, scriptCode{code: `error("failed")`})
+		setCompleted(script, err)
+		setRetry(script, err)
+	}
+
+	It("should classify errors", func() {
+		errorClass := func(err error) scriptsv1.RetryableError {
+			class, _ := errorClass(err)
+			return class
+		}
+		conflict := apierrors.NewConflict(pods, "example", errors.New("modified"))
+		Expect(errorClass(fmt.Errorf("update failed: %w", conflict))).To(Equal(scriptsv1.RetryOnConflict))
+		Expect(errorClass(apierrors.NewTooManyRequests("slow down", 1))).To(Equal(scriptsv1.RetryOnThrottled))
+		Expect(errorClass(apierrors.NewServiceUnavailable("unavailable"))).To(Equal(scriptsv1.RetryOnServerError))
+		Expect(errorClass(apierrors.NewTimeoutError("timeout", 1))).To(Equal(scriptsv1.RetryOnTimeout))
+		Expect(errorClass(errTimedOut)).To(Equal(scriptsv1.RetryOnTimeout))
+		Expect(errorClass(&lua.ScriptError{Message: "attempt to index a nil value"})).To(Equal(scriptsv1.RetryOnScriptError))
+
+		for _, err := range []error{
+			&lua.ScriptError{Message: "syntax error", Compile: true},
+			&lua.LimitError{Limit: "memory", Value: 1},
+			&errCancelled{reason: "spec changed"},
+			apierrors.NewForbidden(pods, "example", errors.New("denied")),
+		} {
+			Expect(errorClass(err)).To(BeEmpty())
+		}
+	})
+
+	It("should back off exponentially up to the maximum", func() {
+		policy := &scriptsv1.RetryPolicy{MaxBackoff: &metav1.Duration{Duration: time.Minute}}
+		Expect(backoff(policy, 1)).To(Equal(10 * time.Second))
+		Expect(backoff(policy, 2)).To(Equal(20 * time.Second))
+		Expect(backoff(policy, 3)).To(Equal(40 * time.Second))
+		Expect(backoff(policy, 4)).To(Equal(time.Minute))
+		Expect(backoff(policy, 100)).To(Equal(time.Minute))
+	})
+
+	It("should retry transient errors a bounded number of times", func() {
+		script := newScript(&scriptsv1.RetryPolicy{MaxRetries: ptr.To[int32](2)})
+		conflict := apierrors.NewConflict(pods, "example", errors.New("modified"))
+
+		fail(script, conflict)
+		Expect(script.Status.Attempts).To(Equal(int32(1)))
+		Expect(script.Status.NextRetryTime).NotTo(BeNil())
+		Expect(script.Status.NextRetryTime.Sub(script.Status.CompletionTime.Time)).To(Equal(10 * time.Second))
+		Expect(retryReason(script)).To(Equal("retry 1 of 2"))
+
+		fail(script, conflict)
+		Expect(script.Status.Attempts).To(Equal(int32(2)))
+		Expect(script.Status.NextRetryTime.Sub(script.Status.CompletionTime.Time)).To(Equal(20 * time.Second))
+
+		fail(script, conflict)
+		Expect(script.Status.Attempts).To(Equal(int32(3)))
+		Expect(script.Status.NextRetryTime).To(BeNil())
+
+		// executions that are not retries start over
+		setRunning(script, scriptCode{code: `error("failed")`})
+		Expect(script.Status.Attempts).To(Equal(int32(1)))
+	})
+
+	It("should only retry the configured error classes", func() {
+		script := newScript(nil)
+		fail(script, apierrors.NewConflict(pods, "example", errors.New("modified")))
+		Expect(script.Status.NextRetryTime).To(BeNil())
+
+		script = newScript(&scriptsv1.RetryPolicy{})
+		fail(script, &lua.ScriptError{Message: "attempt to index a nil value"})
+		Expect(script.Status.NextRetryTime).To(BeNil())
+		fail(script, &lua.ScriptError{Message: "syntax error", Compile: true})
+		Expect(script.Status.NextRetryTime).To(BeNil())
+
+		script = newScript(&scriptsv1.RetryPolicy{RetryOn: []scriptsv1.RetryableError{scriptsv1.RetryOnScriptError}})
+		fail(script, &lua.ScriptError{Message: "attempt to index a nil value"})
+		Expect(script.Status.NextRetryTime).NotTo(BeNil())
+	})
+})
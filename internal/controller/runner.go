@@ -0,0 +1,277 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	scrv1 "github.com/veith4f/scropt/api/v1"
+	"github.com/veith4f/scropt/internal/lua"
+)
+
+// runner executes scripts in the background and keeps track of the executions
+// in flight, so that reconcilers can apply concurrency policies.
+// The zero value is ready to use.
+type runner struct {
+	mu sync.Mutex
+	// executions in flight by script
+	executions map[types.UID]map[*execution]struct{}
+	// most recently started execution by script
+	latest map[types.UID]*execution
+	// time the runner was first used, executions started before were not started by this runner
+	since time.Time
+	// if set, receives the script whenever one of its executions finished
+	events chan event.GenericEvent
+	// generation of the last finished execution by script, until the reconciliation caused by events
+	finished map[types.UID]int64
+	// timeout of executions of scripts without spec.timeout, zero means none
+	defaultTimeout time.Duration
+	// upper bound of the timeout of executions, zero means none
+	maxTimeout time.Duration
+	// memory limit in bytes of executions of scripts without spec.limits.memory, zero means none
+	defaultMemoryLimit int64
+	// executes all scripts as dry run
+	dryRun bool
+	// config of the clients of scripts, if nil scripts use the client of the reconciler
+	config *rest.Config
+	// name of the operator ConfigMap pausing all scripts, empty for none
+	configMap string
+}
+
+type execution struct {
+	key        types.NamespacedName
+	generation int64
+	cancel     context.CancelCauseFunc
+	// releases the timer of the timeout
+	stop context.CancelFunc
+}
+
+// errTimedOut is the cause of executions cancelled because they exceeded their timeout.
+var errTimedOut = errors.New("execution timed out")
+
+// errCancelled is the cause of executions cancelled for any other reason.
+type errCancelled struct {
+	reason string
+}
+
+func (e *errCancelled) Error() string {
+	return "execution cancelled: " + e.reason
+}
+
+func (r *runner) init() {
+	if r.executions == nil {
+		r.executions = make(map[types.UID]map[*execution]struct{})
+		r.latest = make(map[types.UID]*execution)
+		r.finished = make(map[types.UID]int64)
+		r.since = time.Now().Truncate(time.Second)
+	}
+}
+
+// active returns the number of executions of a script in flight.
+func (r *runner) active(script client.Object) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+
+	return len(r.executions[script.GetUID()])
+}
+
+// interrupted returns true if the status of a script claims that it is running
+// although it is not being executed by this runner, e.g. because the operator
+// restarted or leadership moved while it was executing.
+func (r *runner) interrupted(script scriptObject) bool {
+	status := script.GetScriptStatus()
+	if status.Phase != scrv1.ScriptRunning || status.StartTime == nil {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+
+	return len(r.executions[script.GetUID()]) == 0 && status.StartTime.Time.Before(r.since)
+}
+
+// justFinished returns true if the reconciliation of a script was caused by an
+// execution of its current generation that finished, rather than by a change.
+// It reports every finished execution only once.
+func (r *runner) justFinished(script client.Object) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+
+	generation, ok := r.finished[script.GetUID()]
+	delete(r.finished, script.GetUID())
+	return ok && generation == script.GetGeneration()
+}
+
+// startedBefore returns true if t is before the runner was first used, so that
+// an execution started at t cannot have been started by the runner.
+func (r *runner) startedBefore(t time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+
+	return t.Before(r.since)
+}
+
+// cancel cancels all executions of a script in flight.
+func (r *runner) cancel(script client.Object, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+
+	for exec := range r.executions[script.GetUID()] {
+		exec.cancel(&errCancelled{reason: reason})
+	}
+}
+
+// cancelOutdated cancels the executions of a script in flight that were started
+// for a previous generation of its spec.
+func (r *runner) cancelOutdated(script client.Object, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+
+	for exec := range r.executions[script.GetUID()] {
+		if exec.generation != script.GetGeneration() {
+			exec.cancel(&errCancelled{reason: reason})
+		}
+	}
+}
+
+// cancelDeleted cancels the executions of a deleted script, which is only known by key.
+func (r *runner) cancelDeleted(key types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+
+	for _, executions := range r.executions {
+		for exec := range executions {
+			if exec.key == key {
+				exec.cancel(&errCancelled{reason: "script deleted"})
+			}
+		}
+	}
+}
+
+// timeout returns the timeout of an execution of a script.
+func (r *runner) timeout(script scriptObject) time.Duration {
+	timeout := r.defaultTimeout
+	if spec := script.GetScriptSpec(); spec.Timeout != nil {
+		timeout = spec.Timeout.Duration
+	}
+	if r.maxTimeout > 0 && (timeout <= 0 || timeout > r.maxTimeout) {
+		timeout = r.maxTimeout
+	}
+	return timeout
+}
+
+// limits returns the resource limits of an execution of a script.
+func (r *runner) limits(script scriptObject) lua.Limits {
+	limits := lua.Limits{Memory: r.defaultMemoryLimit}
+	spec := script.GetScriptSpec().Limits
+	if spec == nil {
+		return limits
+	}
+	if spec.CallStackSize != nil {
+		limits.CallStackSize = int(*spec.CallStackSize)
+	}
+	if spec.RegistrySize != nil {
+		limits.RegistrySize = int(*spec.RegistrySize)
+	}
+	if spec.Instructions != nil {
+		limits.Instructions = *spec.Instructions
+	}
+	if spec.Memory != nil {
+		limits.Memory = spec.Memory.Value()
+	}
+	return limits
+}
+
+// start executes fn in the background. The context passed to fn is cancelled
+// when the execution is cancelled or times out, with errTimedOut or errCancelled
+// as cause. isLatest reports whether the execution is still the most recently
+// started execution of the script.
+func (r *runner) start(ctx context.Context, script scriptObject, fn func(ctx context.Context, isLatest func() bool)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+
+	uid := script.GetUID()
+	execCtx, cancel := context.WithCancelCause(ctx)
+	stop := context.CancelFunc(func() {})
+	if timeout := r.timeout(script); timeout > 0 {
+		execCtx, stop = context.WithTimeoutCause(execCtx, timeout, errTimedOut)
+	}
+	exec := &execution{
+		key:        client.ObjectKeyFromObject(script),
+		generation: script.GetGeneration(),
+		cancel:     cancel,
+		stop:       stop,
+	}
+	if r.executions[uid] == nil {
+		r.executions[uid] = make(map[*execution]struct{})
+	}
+	r.executions[uid][exec] = struct{}{}
+	r.latest[uid] = exec
+
+	isLatest := func() bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return r.latest[uid] == exec
+	}
+
+	go func() {
+		defer r.finish(ctx, script, exec)
+		fn(execCtx, isLatest)
+	}()
+}
+
+func (r *runner) finish(ctx context.Context, script client.Object, exec *execution) {
+	exec.stop()
+	exec.cancel(nil)
+
+	r.mu.Lock()
+	uid := script.GetUID()
+	delete(r.executions[uid], exec)
+	if len(r.executions[uid]) == 0 {
+		delete(r.executions, uid)
+	}
+	if r.latest[uid] == exec {
+		delete(r.latest, uid)
+	}
+	if r.events != nil {
+		r.finished[uid] = exec.generation
+	}
+	r.mu.Unlock()
+
+	if r.events != nil {
+		select {
+		case r.events <- event.GenericEvent{Object: script}:
+		case <-ctx.Done():
+		}
+	}
+}
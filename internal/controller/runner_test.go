@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+	"github.com/veith4f/scropt/internal/lua"
+)
+
+var _ = Describe("Script runner", func() {
+	newScript := func(timeout *time.Duration) *scriptsv1.LuaScript {
+		script := &scriptsv1.LuaScript{ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			UID:        "1234",
+			Generation: 1,
+		}}
+		if timeout != nil {
+			script.Spec.Timeout = &metav1.Duration{Duration: *timeout}
+		}
+		return script
+	}
+
+	// run starts an execution that blocks until cancelled and returns a channel receiving the cause
+	run := func(r *runner, script scriptObject) <-chan error {
+		cause := make(chan error, 1)
+		r.start(context.Background(), script, func(ctx context.Context, _ func() bool) {
+			<-ctx.Done()
+			cause <- context.Cause(ctx)
+		})
+		return cause
+	}
+
+	It("should cap timeouts at the maximum", func() {
+		long := 2 * time.Hour
+		r := &runner{defaultTimeout: time.Minute, maxTimeout: time.Hour}
+		Expect(r.timeout(newScript(nil))).To(Equal(time.Minute))
+		Expect(r.timeout(newScript(&long))).To(Equal(time.Hour))
+	})
+
+	It("should apply the default memory limit unless overridden", func() {
+		r := &runner{defaultMemoryLimit: 1 << 20}
+		script := newScript(nil)
+		Expect(r.limits(script)).To(Equal(lua.Limits{Memory: 1 << 20}))
+
+		script.Spec.Limits = &scriptsv1.ResourceLimits{
+			CallStackSize: ptr.To[int32](64),
+			Instructions:  ptr.To[int64](1000),
+			Memory:        ptr.To(resource.MustParse("2Mi")),
+		}
+		Expect(r.limits(script)).To(Equal(lua.Limits{CallStackSize: 64, Instructions: 1000, Memory: 2 << 20}))
+	})
+
+	It("should time out executions", func() {
+		timeout := 10 * time.Millisecond
+		cause := run(&runner{}, newScript(&timeout))
+		Eventually(cause).Should(Receive(MatchError(errTimedOut)))
+	})
+
+	It("should fail scripts running longer than their timeout as timed out", func() {
+		timeout := 50 * time.Millisecond
+		script := newScript(&timeout)
+		c := fake.NewClientBuilder().Build()
+		r := &runner{}
+
+		done := make(chan error, 1)
+		r.start(context.Background(), script, func(ctx context.Context, _ func() bool) {
+			_, err := runCode(ctx, c, scriptClients{client: c}, r, script, "LuaScript", compileLua, `while true do end`,
+				lua.WithDiscoveryClient(kubefake.NewSimpleClientset().Discovery()),
+				lua.WithDynamicClient(dynamicfake.NewSimpleDynamicClient(clientgoscheme.Scheme), c.RESTMapper()))
+			done <- err
+		})
+		Eventually(done).Should(Receive(MatchError(errTimedOut)))
+	})
+
+	It("should cancel executions of outdated specs", func() {
+		r := &runner{}
+		script := newScript(nil)
+		cause := run(r, script)
+
+		r.cancelOutdated(script, "spec changed")
+		Consistently(cause, 50*time.Millisecond).ShouldNot(Receive())
+
+		script.Generation++
+		r.cancelOutdated(script, "spec changed")
+		var err error
+		Eventually(cause).Should(Receive(&err))
+		var cancelled *errCancelled
+		Expect(errors.As(err, &cancelled)).To(BeTrue())
+	})
+
+	It("should cancel executions of deleted scripts", func() {
+		r := &runner{}
+		cause := run(r, newScript(nil))
+		r.cancelDeleted(client.ObjectKey{Namespace: "default", Name: "example"})
+		Eventually(cause).Should(Receive(HaveOccurred()))
+	})
+})
@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	scrv1 "github.com/veith4f/scropt/api/v1"
+)
+
+// onTimeTolerance is how late a scheduled execution may be started
+// without being considered missed by the Skip catch-up policy.
+const onTimeTolerance = time.Minute
+
+// maxMissedSchedules is the number of missed executions that are caught up at most,
+// like for CronJobs. Scripts without a starting deadline that missed more executions,
+// e.g. because the operator was down for a long time, skip ahead to the most recent one.
+const maxMissedSchedules = 100
+
+// schedulePlan is the outcome of evaluating the schedule of a script at a given time.
+type schedulePlan struct {
+	// due is the scheduled time an execution should be started for, if any
+	due *time.Time
+	// skipped is the most recent scheduled time that is dropped without execution, if any
+	skipped *time.Time
+	// more is true if further missed executions are pending after due
+	more bool
+	// tooMany is true if more than maxMissedSchedules executions were missed
+	tooMany bool
+	// next is the next scheduled time after now
+	next time.Time
+}
+
+// planSchedule determines which scheduled execution of a script is due at now.
+// Scheduled times are considered from the last scheduled execution recorded in
+// status, or the creation of the script, so that executions missed while the
+// operator was not running are caught up according to the catch-up policy.
+func planSchedule(script scriptObject, now time.Time) (*schedulePlan, error) {
+	spec := script.GetScriptSpec().Schedule
+	status := script.GetScriptStatus()
+
+	loc := time.UTC
+	if spec.TimeZone != nil && *spec.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(*spec.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", *spec.TimeZone, err)
+		}
+	}
+
+	sched, err := cron.ParseStandard(spec.Cron)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", spec.Cron, err)
+	}
+
+	earliest := script.GetCreationTimestamp().Time
+	if status.LastScheduleTime != nil {
+		earliest = status.LastScheduleTime.Time
+	}
+	// executions that cannot be started within the deadline anymore are dropped
+	if spec.StartingDeadlineSeconds != nil {
+		deadline := now.Add(-time.Duration(*spec.StartingDeadlineSeconds) * time.Second)
+		if earliest.Before(deadline) {
+			earliest = deadline
+		}
+	}
+
+	// find the first and the most recent scheduled time since earliest
+	var first, latest *time.Time
+	missed := 0
+	for t := sched.Next(earliest.In(loc)); !t.After(now); t = sched.Next(t) {
+		if first == nil {
+			first = &t
+		}
+		latest = &t
+		missed++
+		if missed > maxMissedSchedules && spec.StartingDeadlineSeconds == nil {
+			break
+		}
+	}
+
+	plan := &schedulePlan{next: sched.Next(now.In(loc))}
+	if latest == nil {
+		return plan, nil
+	}
+	if missed > maxMissedSchedules && spec.StartingDeadlineSeconds == nil {
+		plan.tooMany = true
+		t := latestSchedule(sched, *latest, now.In(loc))
+		latest = &t
+	}
+
+	switch spec.CatchUpPolicy {
+	case scrv1.CatchUpAll:
+		if plan.tooMany {
+			plan.due = latest
+			break
+		}
+		plan.due = first
+		plan.more = missed > 1
+	case scrv1.CatchUpSkip:
+		if now.Sub(*latest) > onTimeTolerance {
+			plan.skipped = latest
+		} else {
+			plan.due = latest
+		}
+	default:
+		plan.due = latest
+	}
+	return plan, nil
+}
+
+// latestSchedule returns the most recent scheduled time at or before now, given
+// that from is a scheduled time before now. Rather than iterating over all scheduled
+// times since from, windows of doubling size before now are searched.
+func latestSchedule(sched cron.Schedule, from, now time.Time) time.Time {
+	for window := time.Minute; ; window *= 2 {
+		start := now.Add(-window)
+		if start.Before(from) {
+			start = from
+		}
+		t := sched.Next(start)
+		if t.After(now) {
+			continue
+		}
+		for next := sched.Next(t); !next.After(now); next = sched.Next(next) {
+			t = next
+		}
+		return t
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+)
+
+var _ = Describe("Script schedule", func() {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newScript := func(schedule scriptsv1.ScheduleSpec, lastScheduleTime *time.Time) *scriptsv1.LuaScript {
+		script := &scriptsv1.LuaScript{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+		}
+		script.Spec.Schedule = &schedule
+		if lastScheduleTime != nil {
+			script.Status.LastScheduleTime = &metav1.Time{Time: *lastScheduleTime}
+		}
+		return script
+	}
+
+	It("should not be due before the first scheduled time", func() {
+		plan, err := planSchedule(newScript(scriptsv1.ScheduleSpec{Cron: "0 * * * *"}, nil), created.Add(30*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.due).To(BeNil())
+		Expect(plan.next).To(Equal(created.Add(time.Hour)))
+	})
+
+	It("should catch up the most recent missed schedule by default", func() {
+		plan, err := planSchedule(newScript(scriptsv1.ScheduleSpec{Cron: "0 * * * *"}, nil), created.Add(150*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*plan.due).To(Equal(created.Add(2 * time.Hour)))
+		Expect(plan.more).To(BeFalse())
+	})
+
+	It("should catch up all missed schedules oldest first", func() {
+		plan, err := planSchedule(newScript(scriptsv1.ScheduleSpec{
+			Cron:          "0 * * * *",
+			CatchUpPolicy: scriptsv1.CatchUpAll,
+		}, nil), created.Add(150*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*plan.due).To(Equal(created.Add(time.Hour)))
+		Expect(plan.more).To(BeTrue())
+	})
+
+	It("should skip missed schedules", func() {
+		plan, err := planSchedule(newScript(scriptsv1.ScheduleSpec{
+			Cron:          "0 * * * *",
+			CatchUpPolicy: scriptsv1.CatchUpSkip,
+		}, nil), created.Add(150*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.due).To(BeNil())
+		Expect(*plan.skipped).To(Equal(created.Add(2 * time.Hour)))
+	})
+
+	It("should drop schedules that missed the starting deadline", func() {
+		deadline := int64(60)
+		last := created.Add(time.Hour)
+		plan, err := planSchedule(newScript(scriptsv1.ScheduleSpec{
+			Cron:                    "0 * * * *",
+			StartingDeadlineSeconds: &deadline,
+		}, &last), created.Add(150*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.due).To(BeNil())
+	})
+
+	It("should skip ahead if too many schedules were missed", func() {
+		now := created.Add(365*24*time.Hour + 30*time.Second)
+		plan, err := planSchedule(newScript(scriptsv1.ScheduleSpec{
+			Cron:          "* * * * *",
+			CatchUpPolicy: scriptsv1.CatchUpAll,
+		}, nil), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.tooMany).To(BeTrue())
+		Expect(*plan.due).To(Equal(now.Truncate(time.Minute)))
+		Expect(plan.more).To(BeFalse())
+	})
+
+	It("should catch up all missed schedules within the starting deadline", func() {
+		deadline := int64(3 * 3600)
+		now := created.Add(365*24*time.Hour + 30*time.Second)
+		plan, err := planSchedule(newScript(scriptsv1.ScheduleSpec{
+			Cron:                    "* * * * *",
+			CatchUpPolicy:           scriptsv1.CatchUpAll,
+			StartingDeadlineSeconds: &deadline,
+		}, nil), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.tooMany).To(BeFalse())
+		Expect(*plan.due).To(Equal(now.Add(-3 * time.Hour).Truncate(time.Minute).Add(time.Minute)))
+		Expect(plan.more).To(BeTrue())
+	})
+
+	It("should interpret the schedule in the given time zone", func() {
+		tz := "Europe/Berlin"
+		plan, err := planSchedule(newScript(scriptsv1.ScheduleSpec{Cron: "0 2 * * *", TimeZone: &tz}, nil), created)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.next.UTC()).To(Equal(created.Add(time.Hour)))
+	})
+
+	It("should reject invalid schedules", func() {
+		_, err := planSchedule(newScript(scriptsv1.ScheduleSpec{Cron: "every minute"}, nil), created)
+		Expect(err).To(HaveOccurred())
+	})
+})
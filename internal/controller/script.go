@@ -0,0 +1,606 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	scrv1 "github.com/veith4f/scropt/api/v1"
+	lua "github.com/veith4f/scropt/internal/lua"
+)
+
+// scriptObject is implemented by all script kinds handled by the reconcilers.
+type scriptObject interface {
+	client.Object
+	GetScriptSpec() *scrv1.ScriptSpec
+	GetScriptStatus() *scrv1.ScriptStatus
+}
+
+// scriptChanged filters out updates of scripts that touch neither spec nor annotations,
+// most notably the status updates written by the reconcilers themselves.
+var scriptChanged = predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{})
+
+// compileFunc translates script code to Lua.
+type compileFunc func(code string) (string, error)
+
+// compileLua is the compileFunc for scripts that are already Lua.
+func compileLua(code string) (string, error) {
+	return code, nil
+}
+
+// reconcileScript decides whether a script is due for execution and starts it in the background.
+// Status is written through the status subresource before and after execution,
+// so that a script is observably Running while it executes.
+func reconcileScript(ctx context.Context, c client.Client, r *runner, t *triggers, script scriptObject, kind string, compile compileFunc) (ctrl.Result, error) {
+	spec := script.GetScriptSpec()
+	status := script.GetScriptStatus()
+
+	if script.GetDeletionTimestamp() != nil {
+		t.remove(client.ObjectKeyFromObject(script))
+		return finalize(ctx, c, r, script, kind, compile)
+	}
+	if err := updateFinalizer(ctx, c, script); err != nil {
+		return ctrl.Result{}, err
+	}
+	// executions of a previous spec are obsolete
+	r.cancelOutdated(script, "spec changed")
+	finished := r.justFinished(script)
+
+	suspended, err := r.suspension(ctx, c, script)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if suspended != nil {
+		// events are dropped while suspended, triggers are registered again once resumed
+		t.remove(client.ObjectKeyFromObject(script))
+		r.cancel(script, suspended.message)
+		patch := client.MergeFrom(script.DeepCopyObject().(client.Object))
+		setSuspended(script, suspended)
+		return ctrl.Result{}, patchStatus(ctx, c, script, patch)
+	}
+	if meta.IsStatusConditionTrue(status.Conditions, scrv1.ConditionSuspended) {
+		log.Printf("Resuming %s: %s", kind, fqn(script))
+		patch := client.MergeFrom(script.DeepCopyObject().(client.Object))
+		setResumed(script)
+		if err := c.Status().Patch(ctx, script, patch); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := t.update(ctx, script); err != nil {
+		log.Printf("Invalid trigger of %s %s: %v", kind, fqn(script), err)
+		patch := client.MergeFrom(script.DeepCopyObject().(client.Object))
+		setInvalid(script, scrv1.ReasonInvalidTrigger, err)
+		return ctrl.Result{}, patchStatus(ctx, c, script, patch)
+	}
+
+	code, err := resolveCode(ctx, c, script)
+	var notFound *errSourceNotFound
+	if errors.As(err, &notFound) {
+		log.Printf("Source of %s %s not found: %v", kind, fqn(script), err)
+		patch := client.MergeFrom(script.DeepCopyObject().(client.Object))
+		setInvalid(script, scrv1.ReasonSourceNotFound, err)
+		return ctrl.Result{}, patchStatus(ctx, c, script, patch)
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	args, err := resolveArgs(ctx, c, script)
+	var invalidArgs *errInvalidArgs
+	if errors.As(err, &invalidArgs) {
+		log.Printf("Invalid args of %s %s: %v", kind, fqn(script), err)
+		patch := client.MergeFrom(script.DeepCopyObject().(client.Object))
+		setInvalid(script, scrv1.ReasonInvalidArgs, err)
+		return ctrl.Result{}, patchStatus(ctx, c, script, patch)
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if code.code == "" {
+		log.Printf("Ignoring empty %s: %s", kind, fqn(script))
+		if status.Phase == scrv1.ScriptPending {
+			return ctrl.Result{}, nil
+		}
+		patch := client.MergeFrom(script.DeepCopyObject().(client.Object))
+		setPending(script, "Script has no code")
+		return ctrl.Result{}, c.Status().Patch(ctx, script, patch)
+	}
+
+	// Patch only the status to prevent conflicts with concurrent spec updates
+	patch := client.MergeFrom(script.DeepCopyObject().(client.Object))
+	result := ctrl.Result{}
+	codeHash := hashCode(code.code)
+
+	var run bool
+	var reason string
+	var scheduled *time.Time
+	concurrency := scrv1.ForbidConcurrent
+	if spec.Schedule != nil {
+		now := time.Now()
+		plan, err := planSchedule(script, now)
+		if err != nil {
+			log.Printf("Invalid schedule of %s %s: %v", kind, fqn(script), err)
+			setInvalid(script, scrv1.ReasonInvalidSchedule, err)
+			return ctrl.Result{}, patchStatus(ctx, c, script, patch)
+		}
+		status.NextScheduleTime = &metav1.Time{Time: plan.next}
+		result.RequeueAfter = plan.next.Sub(now)
+		if plan.tooMany {
+			log.Printf("Too many missed schedules of %s %s, skipping ahead to the most recent one", kind, fqn(script))
+		}
+		if plan.skipped != nil {
+			log.Printf("Skipping missed schedule %s of %s: %s", plan.skipped, kind, fqn(script))
+			status.LastScheduleTime = &metav1.Time{Time: *plan.skipped}
+		}
+		if plan.due != nil {
+			run, reason, scheduled = true, "scheduled at "+plan.due.String(), plan.due
+			// catch up on the remaining missed schedules right away
+			if plan.more {
+				result.RequeueAfter = time.Second
+			}
+		} else if runAtChanged(script) {
+			run, reason = true, "run-at annotation changed"
+		} else if status.StartTime == nil && status.Phase != scrv1.ScriptPending {
+			setPending(script, "Waiting for next schedule")
+		}
+		concurrency = spec.Schedule.ConcurrencyPolicy
+	} else if len(spec.Triggers) > 0 {
+		if runAtChanged(script) {
+			run, reason = true, "run-at annotation changed"
+		} else if r.interrupted(script) {
+			// the event is lost, it is not replayed
+			setCompleted(script, errors.New("execution was interrupted"))
+		} else if status.StartTime == nil && status.Phase != scrv1.ScriptPending {
+			setPending(script, "Waiting for events")
+		}
+	} else {
+		run, reason = shouldRun(script, codeHash, r.interrupted(script), finished)
+	}
+
+	// triggered scripts retry failed executions for the same event right away, see runTriggered
+	retry := false
+	if !run && status.NextRetryTime != nil && len(spec.Triggers) == 0 {
+		if wait := time.Until(status.NextRetryTime.Time); wait > 0 {
+			if result.RequeueAfter == 0 || wait < result.RequeueAfter {
+				result.RequeueAfter = wait
+			}
+		} else {
+			run, reason, retry = true, retryReason(script), true
+		}
+	}
+
+	if run && r.active(script) > 0 {
+		switch concurrency {
+		case scrv1.ForbidConcurrent:
+			// the runner requeues the script once the running execution finished
+			log.Printf("Postponing %s (%s) until running execution finished: %s", kind, reason, fqn(script))
+			return result, patchStatus(ctx, c, script, patch)
+		case scrv1.ReplaceConcurrent:
+			log.Printf("Cancelling running execution of %s: %s", kind, fqn(script))
+			r.cancel(script, "replaced by a new execution")
+		}
+	}
+
+	if !run {
+		return result, patchStatus(ctx, c, script, patch)
+	}
+
+	log.Printf("Running %s (%s): %s", kind, reason, fqn(script))
+	if !retry {
+		// executions for any other reason start over with the first attempt
+		status.NextRetryTime = nil
+	}
+	if scheduled != nil {
+		status.LastScheduleTime = &metav1.Time{Time: *scheduled}
+	}
+	if _, err := execute(ctx, c, r, script, patch, kind, reason, compile, code, lua.WithReadOnlyGlobal("args", args)); err != nil {
+		return ctrl.Result{}, err
+	}
+	return result, nil
+}
+
+// runTriggered executes the script identified by key for an event of one of
+// its triggers and waits for the execution to finish, including its retries.
+// script is an empty object of the script kind the script is fetched into.
+func runTriggered(ctx context.Context, c client.Client, r *runner, script scriptObject, key types.NamespacedName, kind string, compile compileFunc, ev triggerEvent) {
+	if err := c.Get(ctx, key, script); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			log.Printf("Failed fetching %s for %s event: %v", kind, ev.Type, err)
+		}
+		return
+	}
+	if script.GetDeletionTimestamp() != nil {
+		return
+	}
+	if suspended, err := r.suspension(ctx, c, script); err != nil {
+		log.Printf("Failed checking suspension of %s for %s event: %s: %v", kind, ev.Type, fqn(script), err)
+		return
+	} else if suspended != nil {
+		log.Printf("Skipping %s event of suspended %s: %s", ev.Type, kind, fqn(script))
+		return
+	}
+	code, err := resolveCode(ctx, c, script)
+	if err != nil {
+		// the script is marked as failed by the next reconciliation
+		log.Printf("Failed loading code of %s for %s event: %s: %v", kind, ev.Type, fqn(script), err)
+		return
+	}
+	if code.code == "" {
+		return
+	}
+	args, err := resolveArgs(ctx, c, script)
+	if err != nil {
+		log.Printf("Failed resolving args of %s for %s event: %s: %v", kind, ev.Type, fqn(script), err)
+		return
+	}
+
+	evReason := fmt.Sprintf("%s event of %s %s", ev.Type, ev.Object.GetKind(), fqn(ev.Object))
+	reason := evReason
+	patch := client.MergeFrom(script.DeepCopyObject().(client.Object))
+	// every event starts over with the first attempt
+	script.GetScriptStatus().NextRetryTime = nil
+	for {
+		log.Printf("Running %s (%s): %s", kind, reason, fqn(script))
+		done, err := execute(ctx, c, r, script, patch, kind, reason, compile, code,
+			lua.WithReadOnlyGlobal("args", args), lua.WithGlobal("event", ev.global()))
+		if err != nil {
+			return
+		}
+		var status *scrv1.ScriptStatus
+		select {
+		case status = <-done:
+		case <-ctx.Done():
+			return
+		}
+		if status == nil || status.NextRetryTime == nil {
+			return
+		}
+
+		log.Printf("Retrying %s (%s) at %s: %s", kind, evReason, status.NextRetryTime, fqn(script))
+		select {
+		case <-time.After(time.Until(status.NextRetryTime.Time)):
+		case <-ctx.Done():
+			return
+		}
+		if err := c.Get(ctx, key, script); err != nil {
+			log.Printf("Failed fetching %s for retry of %s: %v", kind, evReason, err)
+			return
+		}
+		// executions of a previous spec are not retried
+		if script.GetDeletionTimestamp() != nil || script.GetGeneration() != status.ObservedGeneration {
+			return
+		}
+		if suspended, err := r.suspension(ctx, c, script); err != nil || suspended != nil {
+			return
+		}
+		// the cache may not reflect the status written on completion yet
+		*script.GetScriptStatus() = *status
+		patch = client.MergeFrom(script.DeepCopyObject().(client.Object))
+		reason = evReason + ", " + retryReason(script)
+	}
+}
+
+// execute marks a script as Running and executes it in the background, recording the
+// execution in a ScriptRun. patch must be based on the script as it was before the caller
+// modified its status. The returned channel receives the status written once the execution
+// finished, or nil if a more recent execution owns the status.
+func execute(ctx context.Context, c client.Client, r *runner, script scriptObject, patch client.Patch, kind, reason string, compile compileFunc, code scriptCode, opts ...lua.Option) (<-chan *scrv1.ScriptStatus, error) {
+	setRunning(script, code)
+	run := startRun(ctx, c, r, script, reason)
+	if run != nil {
+		script.GetScriptStatus().LastRun = run.Name
+	}
+	if err := c.Status().Patch(ctx, script, patch); err != nil {
+		log.Printf("Failed updating %s status to %v: %s", kind, err, fqn(script))
+		if run != nil {
+			// the execution is retried with a new run
+			if err := c.Delete(context.WithoutCancel(ctx), run); client.IgnoreNotFound(err) != nil {
+				log.Printf("Failed deleting ScriptRun %s/%s: %v", run.Namespace, run.Name, err)
+			}
+		}
+		return nil, err
+	}
+
+	// the status as written above is the base for the status written on completion
+	running := script.DeepCopyObject().(scriptObject)
+	done := make(chan *scrv1.ScriptStatus, 1)
+	r.start(ctx, running, func(ctx context.Context, isLatest func() bool) {
+		defer close(done)
+
+		output := &runOutput{}
+		opts := append(opts, lua.WithOutput(output))
+		var result json.RawMessage
+		clients, err := r.clients(ctx, c, running)
+		if err == nil {
+			result, err = runCode(ctx, c, clients, r, running, kind, compile, code.code, opts...)
+		}
+		if err == nil {
+			err = recordResult(context.WithoutCancel(ctx), c, running, result)
+		}
+		if err != nil {
+			log.Printf("Execution of %s failed: %s: %v", kind, fqn(running), err)
+		}
+
+		patch := client.MergeFrom(running.DeepCopyObject().(client.Object))
+		setCompleted(running, err)
+		setRetry(running, err)
+		setDryRun(running, clients.recorder)
+		completeRun(context.WithoutCancel(ctx), c, r, run, running, output)
+
+		// a more recent execution owns the status
+		if !isLatest() {
+			return
+		}
+		// the status is written even if the execution was cancelled
+		if err := c.Status().Patch(context.WithoutCancel(ctx), running, patch); err != nil {
+			log.Printf("Failed updating %s status to %v: %s", kind, err, fqn(running))
+		}
+		done <- running.GetScriptStatus().DeepCopy()
+	})
+	return done, nil
+}
+
+// runCode compiles and executes code of a script with the bindings shared by all of its executions.
+// Modules are loaded through c, the script accesses the API server through clients.
+func runCode(ctx context.Context, c client.Client, clients scriptClients, r *runner, script scriptObject, kind string, compile compileFunc, code string, opts ...lua.Option) (json.RawMessage, error) {
+	log.Printf("Compiling %s: %s", kind, fqn(script))
+	luaCode, err := compile(code)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Executing %s: %s", kind, fqn(script))
+	opts = append([]lua.Option{
+		lua.WithModuleLoader(moduleLoader(ctx, c, script.GetNamespace())),
+		lua.WithLimits(r.limits(script)),
+	}, opts...)
+	if clients.discovery != nil {
+		opts = append(opts, lua.WithDiscoveryClient(clients.discovery))
+	}
+	if clients.dynamic != nil {
+		opts = append(opts, lua.WithDynamicClient(clients.dynamic, c.RESTMapper()))
+	}
+	result, err := lua.Exec(ctx, luaCode, clients.client, opts...)
+	// report why the execution was aborted rather than the error raised by the Lua runtime
+	if err != nil && ctx.Err() != nil {
+		err = context.Cause(ctx)
+	}
+	return result, err
+}
+
+// shouldRun decides whether a script needs to be executed according to its
+// run policy and the scropt.io/run-at annotation. It also returns the reason.
+// finished tells whether the reconciliation was caused by an execution of the
+// current spec that finished, which does not execute scripts with run policy
+// Always again, as they would otherwise run back-to-back.
+func shouldRun(script scriptObject, codeHash string, interrupted, finished bool) (bool, string) {
+	status := script.GetScriptStatus()
+
+	if runAtChanged(script) {
+		return true, "run-at annotation changed"
+	}
+	if status.StartTime == nil {
+		return true, "never executed"
+	}
+	if interrupted {
+		return true, "execution was interrupted"
+	}
+
+	switch script.GetScriptSpec().RunPolicy {
+	case scrv1.RunOnce:
+		return false, ""
+	case scrv1.RunAlways:
+		if finished {
+			return false, ""
+		}
+		return true, "run policy is Always"
+	default:
+		if status.ObservedGeneration != script.GetGeneration() {
+			return true, "spec changed"
+		}
+		if wasInvalid(script) {
+			return true, "inputs became valid"
+		}
+		if status.CodeHash != codeHash {
+			return true, "code changed"
+		}
+		return false, ""
+	}
+}
+
+// wasInvalid returns true if the script was last found invalid by setInvalid.
+func wasInvalid(script scriptObject) bool {
+	cond := meta.FindStatusCondition(script.GetScriptStatus().Conditions, scrv1.ConditionSucceeded)
+	if cond == nil || cond.Status != metav1.ConditionFalse {
+		return false
+	}
+	switch cond.Reason {
+	case scrv1.ReasonInvalidArgs, scrv1.ReasonSourceNotFound:
+		return true
+	}
+	return false
+}
+
+// patchStatus writes the status of a script unless the patch is empty.
+func patchStatus(ctx context.Context, c client.Client, script scriptObject, patch client.Patch) error {
+	data, err := patch.Data(script)
+	if err != nil {
+		return err
+	}
+	if string(data) == "{}" {
+		return nil
+	}
+	return c.Status().Patch(ctx, script, patch)
+}
+
+// runAtChanged returns true if the scropt.io/run-at annotation changed since the last execution.
+func runAtChanged(script scriptObject) bool {
+	runAt, ok := script.GetAnnotations()[scrv1.RunAtAnnotation]
+	return ok && runAt != script.GetScriptStatus().ObservedRunAt
+}
+
+// setPending marks a script as waiting for execution.
+func setPending(script scriptObject, message string) {
+	status := script.GetScriptStatus()
+	status.Phase = scrv1.ScriptPending
+	status.ObservedGeneration = script.GetGeneration()
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scrv1.ConditionSucceeded,
+		Status:             metav1.ConditionUnknown,
+		Reason:             scrv1.ReasonPending,
+		Message:            message,
+		ObservedGeneration: script.GetGeneration(),
+	})
+}
+
+// setInvalid marks a script that cannot be executed because its spec is invalid.
+func setInvalid(script scriptObject, reason string, err error) {
+	status := script.GetScriptStatus()
+	status.Phase = scrv1.ScriptFailed
+	status.ObservedGeneration = script.GetGeneration()
+	status.Error = &scrv1.ScriptError{Message: err.Error()}
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scrv1.ConditionSucceeded,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            err.Error(),
+		ObservedGeneration: script.GetGeneration(),
+	})
+}
+
+// setRunning resets the status of a script that is about to be executed.
+func setRunning(script scriptObject, code scriptCode) {
+	status := script.GetScriptStatus()
+	now := metav1.Now()
+
+	status.Phase = scrv1.ScriptRunning
+	status.ObservedGeneration = script.GetGeneration()
+	status.StartTime = &now
+	status.CompletionTime = nil
+	status.CodeHash = hashCode(code.code)
+	status.SourceRevision = code.revision
+	status.Error = nil
+	status.Result = nil
+	status.ResultConfigMap = ""
+	status.Mutations = nil
+	status.ObservedRunAt = script.GetAnnotations()[scrv1.RunAtAnnotation]
+	// retries are scheduled by setRetry, other executions are the first attempt
+	if status.NextRetryTime != nil {
+		status.Attempts++
+	} else {
+		status.Attempts = 1
+	}
+	status.NextRetryTime = nil
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scrv1.ConditionRunning,
+		Status:             metav1.ConditionTrue,
+		Reason:             scrv1.ReasonExecuting,
+		Message:            "Script is being executed",
+		ObservedGeneration: script.GetGeneration(),
+	})
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scrv1.ConditionSucceeded,
+		Status:             metav1.ConditionUnknown,
+		Reason:             scrv1.ReasonExecuting,
+		Message:            "Script is being executed",
+		ObservedGeneration: script.GetGeneration(),
+	})
+}
+
+// setCompleted records the outcome of an execution in the status of a script.
+func setCompleted(script scriptObject, err error) {
+	status := script.GetScriptStatus()
+	now := metav1.Now()
+
+	status.CompletionTime = &now
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scrv1.ConditionRunning,
+		Status:             metav1.ConditionFalse,
+		Reason:             scrv1.ReasonCompleted,
+		Message:            "Script is not being executed",
+		ObservedGeneration: script.GetGeneration(),
+	})
+
+	if err == nil {
+		status.Phase = scrv1.ScriptSucceeded
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scrv1.ConditionSucceeded,
+			Status:             metav1.ConditionTrue,
+			Reason:             scrv1.ReasonCompleted,
+			Message:            "Script was executed successfully",
+			ObservedGeneration: script.GetGeneration(),
+		})
+		return
+	}
+
+	status.Phase = scrv1.ScriptFailed
+	status.Error = toScriptError(err)
+
+	reason := scrv1.ReasonExecFailed
+	var scriptErr *lua.ScriptError
+	var resultErr *errInvalidResult
+	var cancelled *errCancelled
+	var limitErr *lua.LimitError
+	switch {
+	case errors.As(err, &scriptErr) && scriptErr.Compile:
+		reason = scrv1.ReasonCompileFailed
+	case errors.As(err, &resultErr):
+		reason = scrv1.ReasonInvalidResult
+	case errors.Is(err, errTimedOut):
+		reason = scrv1.ReasonTimedOut
+	case errors.As(err, &cancelled):
+		reason = scrv1.ReasonCancelled
+	case errors.As(err, &limitErr):
+		reason = scrv1.ReasonResourceLimitExceeded
+	}
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scrv1.ConditionSucceeded,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            status.Error.String(),
+		ObservedGeneration: script.GetGeneration(),
+	})
+}
+
+// toScriptError converts an error returned by compilation or execution to its API representation.
+func toScriptError(err error) *scrv1.ScriptError {
+	var scriptErr *lua.ScriptError
+	if !errors.As(err, &scriptErr) {
+		return &scrv1.ScriptError{Message: err.Error()}
+	}
+	return &scrv1.ScriptError{
+		Message: scriptErr.Message,
+		Line:    int32(scriptErr.Line),
+		Column:  int32(scriptErr.Column),
+	}
+}
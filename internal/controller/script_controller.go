@@ -0,0 +1,212 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	scrv1 "github.com/veith4f/scropt/api/v1"
+	scrv2 "github.com/veith4f/scropt/api/v2"
+	lua "github.com/veith4f/scropt/internal/lua"
+)
+
+// compilers translate the code of Scripts to Lua by language.
+var compilers = map[scrv2.Language]compileFunc{
+	scrv2.LanguageLua:        compileLua,
+	scrv2.LanguageMoonScript: lua.CompileMoonscript,
+}
+
+// compilerFor returns the compileFunc of a language. Code of unknown languages fails to compile.
+func compilerFor(language scrv2.Language) compileFunc {
+	if compile, ok := compilers[language]; ok {
+		return compile
+	}
+	return func(string) (string, error) {
+		return "", fmt.Errorf("unsupported language %q", language)
+	}
+}
+
+// ScriptKind is a kind of script executed by a ScriptReconciler.
+type ScriptKind struct {
+	// Name of the kind, e.g. "LuaScript".
+	Name string
+
+	newObject func() scriptObject
+	newList   func() client.ObjectList
+	// cluster-scoped scripts may reference sources in any namespace
+	clusterScoped bool
+	// compile returns the compileFunc for the code of a script
+	compile func(script scriptObject) compileFunc
+	// skip returns true if a script must not be reconciled yet, optional
+	skip func(script scriptObject) bool
+}
+
+// compileWith returns a ScriptKind.compile that compiles the code of every script with compile.
+func compileWith(compile compileFunc) func(scriptObject) compileFunc {
+	return func(scriptObject) compileFunc {
+		return compile
+	}
+}
+
+// Kinds of scripts, v1 kinds each have a fixed language.
+var (
+	LuaScriptKind = ScriptKind{
+		Name:      "LuaScript",
+		newObject: func() scriptObject { return &scrv1.LuaScript{} },
+		newList:   func() client.ObjectList { return &scrv1.LuaScriptList{} },
+		compile:   compileWith(compileLua),
+	}
+	MoonScriptKind = ScriptKind{
+		Name:      "MoonScript",
+		newObject: func() scriptObject { return &scrv1.MoonScript{} },
+		newList:   func() client.ObjectList { return &scrv1.MoonScriptList{} },
+		compile:   compileWith(lua.CompileMoonscript),
+	}
+	ClusterLuaScriptKind = ScriptKind{
+		Name:          "ClusterLuaScript",
+		newObject:     func() scriptObject { return &scrv1.ClusterLuaScript{} },
+		newList:       func() client.ObjectList { return &scrv1.ClusterLuaScriptList{} },
+		clusterScoped: true,
+		compile:       compileWith(compileLua),
+	}
+	ClusterMoonScriptKind = ScriptKind{
+		Name:          "ClusterMoonScript",
+		newObject:     func() scriptObject { return &scrv1.ClusterMoonScript{} },
+		newList:       func() client.ObjectList { return &scrv1.ClusterMoonScriptList{} },
+		clusterScoped: true,
+		compile:       compileWith(lua.CompileMoonscript),
+	}
+	// V2ScriptKind executes Scripts of any language, compiling code by spec.language.
+	V2ScriptKind = ScriptKind{
+		Name:      "Script",
+		newObject: func() scriptObject { return &scrv2.Script{} },
+		newList:   func() client.ObjectList { return &scrv2.ScriptList{} },
+		compile: func(script scriptObject) compileFunc {
+			return compilerFor(script.(*scrv2.Script).Spec.Language)
+		},
+		// the status of the v1 script is yet to be copied, executing now might repeat its last execution
+		skip: func(script scriptObject) bool {
+			return migrating(script.(*scrv2.Script))
+		},
+	}
+)
+
+// ScriptKinds are the kinds of scripts executed by the operator.
+var ScriptKinds = []ScriptKind{LuaScriptKind, MoonScriptKind, ClusterLuaScriptKind, ClusterMoonScriptKind, V2ScriptKind}
+
+// ScriptReconciler reconciles scripts of a kind, i.e. executes them according
+// to their spec and reports the outcome in their status.
+type ScriptReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Kind of the reconciled scripts.
+	Kind ScriptKind
+	// DefaultTimeout is the timeout of executions of scripts without spec.timeout, zero means none.
+	DefaultTimeout time.Duration
+	// MaxTimeout caps the timeout of executions, zero means no maximum.
+	MaxTimeout time.Duration
+	// DefaultMemoryLimit is the memory limit in bytes of executions of scripts
+	// without spec.limits.memory, zero means none.
+	DefaultMemoryLimit int64
+	// DryRun executes all scripts as dry run, regardless of spec.dryRun.
+	DryRun bool
+	// ConfigMapName is the name of the operator ConfigMap in the namespace of the operator.
+	// Setting its key "paused" to "true" suspends all scripts. Empty disables the global pause.
+	ConfigMapName string
+
+	runner   runner
+	triggers triggers
+}
+
+// +kubebuilder:rbac:groups=scripts.scropt.io,resources=luascripts;moonscripts;clusterluascripts;clustermoonscripts;scripts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=scripts.scropt.io,resources=luascripts/status;moonscripts/status;clusterluascripts/status;clustermoonscripts/status;scripts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=scripts.scropt.io,resources=luascripts/finalizers;moonscripts/finalizers;clusterluascripts/finalizers;clustermoonscripts/finalizers;scripts/finalizers,verbs=update
+// +kubebuilder:rbac:groups=scripts.scropt.io,resources=luamodules;clusterluamodules,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=create;update;patch
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=impersonate
+// +kubebuilder:rbac:groups=*,resources=*,verbs=get;list;watch
+
+// Reconcile executes a script of the kind of the reconciler through reconcileScript.
+func (r *ScriptReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	// Fetch Script resource
+	script := r.Kind.newObject()
+	if err := r.Get(ctx, req.NamespacedName, script); err != nil {
+		log.Printf("%s resource not found, ignoring", r.Kind.Name)
+		if apierrors.IsNotFound(err) {
+			r.runner.cancelDeleted(req.NamespacedName)
+			r.triggers.remove(req.NamespacedName)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if r.Kind.skip != nil && r.Kind.skip(script) {
+		return ctrl.Result{}, nil
+	}
+
+	return reconcileScript(ctx, r.Client, &r.runner, &r.triggers, script, r.Kind.Name, r.Kind.compile(script))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ScriptReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := indexSources(context.Background(), mgr, r.Kind.newObject()); err != nil {
+		return err
+	}
+	r.runner.events = make(chan event.GenericEvent)
+	r.runner.defaultTimeout = r.DefaultTimeout
+	r.runner.maxTimeout = r.MaxTimeout
+	r.runner.defaultMemoryLimit = r.DefaultMemoryLimit
+	r.runner.dryRun = r.DryRun
+	r.runner.configMap = r.ConfigMapName
+	r.runner.config = mgr.GetConfig()
+	c, err := ctrl.NewControllerManagedBy(mgr).
+		For(r.Kind.newObject(), builder.WithPredicates(scriptChanged)).
+		Watches(&corev1.ConfigMap{}, enqueueForSource(mgr.GetClient(), r.Kind.newList(), configMapSourceIndex, r.Kind.clusterScoped)).
+		Watches(&corev1.ConfigMap{}, enqueueForConfigMap(mgr.GetClient(), r.Kind.newList(), r.ConfigMapName)).
+		Watches(&corev1.Secret{}, enqueueForSource(mgr.GetClient(), r.Kind.newList(), secretSourceIndex, r.Kind.clusterScoped)).
+		WatchesRawSource(source.Channel(r.runner.events, &handler.EnqueueRequestForObject{})).
+		Named(strings.ToLower(r.Kind.Name)).
+		Build(r)
+	if err != nil {
+		return err
+	}
+	r.triggers.setup(mgr, c, func(ctx context.Context, key types.NamespacedName, ev triggerEvent) {
+		script := r.Kind.newObject()
+		// the compileFunc of a script is only known once runTriggered fetched it
+		compile := func(code string) (string, error) {
+			return r.Kind.compile(script)(code)
+		}
+		runTriggered(ctx, r.Client, &r.runner, script, key, r.Kind.Name, compile, ev)
+	})
+	return nil
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+)
+
+var _ = Describe("Script run policy", func() {
+	const code = `return 1`
+
+	// executed returns a script that was executed once with code
+	executed := func(policy scriptsv1.RunPolicy) *scriptsv1.LuaScript {
+		script := &scriptsv1.LuaScript{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default", Generation: 1}}
+		script.Spec.RunPolicy = policy
+		setRunning(script, scriptCode{code: code})
+		setCompleted(script, nil)
+		return script
+	}
+
+	// due returns whether the script should run, ignoring the reason
+	due := func(script *scriptsv1.LuaScript, code string) bool {
+		run, _ := shouldRun(script, code, false, false)
+		return run
+	}
+
+	It("should execute scripts that were never executed", func() {
+		for _, policy := range []scriptsv1.RunPolicy{scriptsv1.RunOnce, scriptsv1.RunOnChange, scriptsv1.RunAlways} {
+			script := &scriptsv1.LuaScript{ObjectMeta: metav1.ObjectMeta{Generation: 1}}
+			script.Spec.RunPolicy = policy
+			run, reason := shouldRun(script, hashCode(code), false, false)
+			Expect(run).To(BeTrue())
+			Expect(reason).To(Equal("never executed"))
+		}
+	})
+
+	It("should execute Once scripts a single time", func() {
+		script := executed(scriptsv1.RunOnce)
+		Expect(due(script, hashCode(code))).To(BeFalse())
+
+		script.Generation = 2
+		Expect(due(script, hashCode(`return 2`))).To(BeFalse())
+	})
+
+	It("should execute OnChange scripts when the spec or the code changes", func() {
+		script := executed(scriptsv1.RunOnChange)
+		Expect(due(script, hashCode(code))).To(BeFalse())
+
+		// the default policy is OnChange
+		script.Spec.RunPolicy = ""
+		Expect(due(script, hashCode(code))).To(BeFalse())
+
+		run, reason := shouldRun(script, hashCode(`return 2`), false, false)
+		Expect(run).To(BeTrue())
+		Expect(reason).To(Equal("code changed"))
+
+		script.Generation = 2
+		_, reason = shouldRun(script, hashCode(code), false, false)
+		Expect(reason).To(Equal("spec changed"))
+	})
+
+	It("should execute OnChange scripts again when their inputs become valid", func() {
+		script := executed(scriptsv1.RunOnChange)
+		setInvalid(script, scriptsv1.ReasonInvalidArgs, errors.New("missing arg"))
+		run, reason := shouldRun(script, hashCode(code), false, false)
+		Expect(run).To(BeTrue())
+		Expect(reason).To(Equal("inputs became valid"))
+	})
+
+	It("should execute Always scripts on every reconciliation", func() {
+		script := executed(scriptsv1.RunAlways)
+		Expect(due(script, hashCode(code))).To(BeTrue())
+	})
+
+	It("should execute interrupted scripts again regardless of the policy", func() {
+		script := executed(scriptsv1.RunOnce)
+		run, reason := shouldRun(script, hashCode(code), true, false)
+		Expect(run).To(BeTrue())
+		Expect(reason).To(Equal("execution was interrupted"))
+	})
+
+	It("should execute scripts again when the run-at annotation changes", func() {
+		for _, policy := range []scriptsv1.RunPolicy{scriptsv1.RunOnce, scriptsv1.RunOnChange} {
+			script := executed(policy)
+			Expect(due(script, hashCode(code))).To(BeFalse())
+
+			// the generation does not change with annotations
+			script.Annotations = map[string]string{scriptsv1.RunAtAnnotation: "2025-01-01T00:00:00Z"}
+			run, reason := shouldRun(script, hashCode(code), false, false)
+			Expect(run).To(BeTrue())
+			Expect(reason).To(Equal("run-at annotation changed"))
+
+			// the execution records the annotation, so that it triggers only once
+			setRunning(script, scriptCode{code: code})
+			setCompleted(script, nil)
+			Expect(script.Status.ObservedRunAt).To(Equal("2025-01-01T00:00:00Z"))
+			Expect(due(script, hashCode(code))).To(BeFalse())
+
+			script.Annotations[scriptsv1.RunAtAnnotation] = "2025-01-02T00:00:00Z"
+			Expect(due(script, hashCode(code))).To(BeTrue())
+		}
+	})
+
+	It("should not execute Always scripts again when their execution finished", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(scriptsv1.AddToScheme(scheme)).To(Succeed())
+
+		// scripts get clients from the kubeconfig, which are not used by the code
+		kubeconfig := filepath.Join(GinkgoT().TempDir(), "kubeconfig")
+		Expect(os.WriteFile(kubeconfig, []byte(`
+apiVersion: v1
+kind: Config
+clusters: [{name: test, cluster: {server: "https://127.0.0.1:6443"}}]
+contexts: [{name: test, context: {cluster: test}}]
+current-context: test
+`), 0o600)).To(Succeed())
+		GinkgoT().Setenv("KUBECONFIG", kubeconfig)
+
+		script := &scriptsv1.LuaScript{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default", UID: "1234"}}
+		script.Spec.RunPolicy = scriptsv1.RunAlways
+		script.Spec.Code = code
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(script).
+			WithStatusSubresource(script, &scriptsv1.ScriptRun{}).Build()
+		r := &runner{events: make(chan event.GenericEvent, 1)}
+
+		reconcile := func() {
+			GinkgoHelper()
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(script), script)).To(Succeed())
+			_, err := reconcileScript(ctx, c, r, &triggers{}, script, "LuaScript", compileLua)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		runs := func() []scriptsv1.ScriptRun {
+			list := &scriptsv1.ScriptRunList{}
+			Expect(c.List(ctx, list)).To(Succeed())
+			return list.Items
+		}
+
+		reconcile()
+		Eventually(r.events).Should(Receive())
+		Expect(runs()).To(HaveLen(1))
+
+		// the reconciliation caused by the finished execution
+		reconcile()
+		Expect(r.active(script)).To(BeZero())
+		Consistently(r.events, "100ms").ShouldNot(Receive())
+		Expect(runs()).To(HaveLen(1))
+
+		// any other reconciliation, e.g. a resync
+		reconcile()
+		Eventually(r.events).Should(Receive())
+		Expect(runs()).To(HaveLen(2))
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(script), script)).To(Succeed())
+		Expect(script.Status.Phase).To(Equal(scriptsv1.ScriptSucceeded))
+	})
+})
@@ -0,0 +1,214 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scrv1 "github.com/veith4f/scropt/api/v1"
+)
+
+// maxRunOutput is the maximum size in bytes of the output recorded in a ScriptRun.
+const maxRunOutput = 16 << 10
+
+// History limits of scripts without spec.successfulRunsHistoryLimit or spec.failedRunsHistoryLimit.
+const (
+	defaultSuccessfulRunsHistoryLimit = 3
+	defaultFailedRunsHistoryLimit     = 1
+)
+
+// runOutput collects the output of an execution up to maxRunOutput bytes.
+type runOutput struct {
+	mu        sync.Mutex
+	buf       []byte
+	truncated bool
+}
+
+// Write implements io.Writer. Output beyond maxRunOutput is dropped.
+func (o *runOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	n := min(len(p), maxRunOutput-len(o.buf))
+	o.buf = append(o.buf, p[:n]...)
+	o.truncated = o.truncated || n < len(p)
+	return len(p), nil
+}
+
+// startRun creates the ScriptRun of an execution of a script that is about to start,
+// as marked by setRunning. Failures are logged, the execution is not recorded then.
+func startRun(ctx context.Context, c client.Client, r *runner, script scriptObject, reason string) *scrv1.ScriptRun {
+	gvk, err := c.GroupVersionKindFor(script)
+	if err != nil {
+		log.Printf("Failed creating ScriptRun of %s: %v", fqn(script), err)
+		return nil
+	}
+	spec := script.GetScriptSpec()
+	status := script.GetScriptStatus()
+
+	// generated names and label values are limited to 63 characters,
+	// runs are selected by the UID label, the name label is informational
+	run := &scrv1.ScriptRun{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: truncateName(script.GetName(), 57) + "-",
+			Namespace:    referenceNamespace(script),
+			Labels: map[string]string{
+				scrv1.ScriptRunScriptLabel:    truncateName(script.GetName(), validation.LabelValueMaxLength),
+				scrv1.ScriptRunScriptUIDLabel: string(script.GetUID()),
+			},
+		},
+		Spec: scrv1.ScriptRunSpec{
+			ScriptRef: scrv1.ScriptReference{
+				APIVersion: gvk.GroupVersion().String(),
+				Kind:       gvk.Kind,
+				Name:       script.GetName(),
+				Namespace:  script.GetNamespace(),
+			},
+			Reason:           reason,
+			Generation:       script.GetGeneration(),
+			CodeHash:         status.CodeHash,
+			SourceRevision:   status.SourceRevision,
+			Attempt:          status.Attempts,
+			DryRun:           r.dryRun || spec.DryRun,
+			TTLAfterFinished: spec.RunTTL,
+		},
+	}
+	if err := controllerutil.SetOwnerReference(script, run, c.Scheme()); err != nil {
+		log.Printf("Failed creating ScriptRun of %s: %v", fqn(script), err)
+		return nil
+	}
+	if err := c.Create(ctx, run); err != nil {
+		log.Printf("Failed creating ScriptRun of %s: %v", fqn(script), err)
+		return nil
+	}
+
+	patch := client.MergeFrom(run.DeepCopy())
+	run.Status.Phase = scrv1.ScriptRunning
+	run.Status.StartTime = status.StartTime
+	if err := c.Status().Patch(ctx, run, patch); err != nil {
+		log.Printf("Failed updating ScriptRun status to %v: %s/%s", err, run.Namespace, run.Name)
+	}
+	return run
+}
+
+// truncateName shortens the name of an object to at most n characters ending
+// in an alphanumeric character, so that it is valid as label value and name prefix.
+func truncateName(name string, n int) string {
+	if len(name) <= n {
+		return name
+	}
+	return strings.TrimRight(name[:n], "-.")
+}
+
+// completeRun records the outcome of an execution in its ScriptRun, as set in the status
+// of the script by setCompleted, and deletes ScriptRuns beyond the history limits.
+func completeRun(ctx context.Context, c client.Client, r *runner, run *scrv1.ScriptRun, script scriptObject, output *runOutput) {
+	if run == nil {
+		return
+	}
+	status := script.GetScriptStatus()
+	patch := client.MergeFrom(run.DeepCopy())
+	run.Status.Phase = status.Phase
+	run.Status.CompletionTime = status.CompletionTime
+	run.Status.Error = status.Error
+	run.Status.Result = status.Result
+	run.Status.ResultConfigMap = status.ResultConfigMap
+	run.Status.Mutations = status.Mutations
+	output.mu.Lock()
+	run.Status.Output, run.Status.OutputTruncated = string(output.buf), output.truncated
+	output.mu.Unlock()
+	if err := c.Status().Patch(ctx, run, patch); err != nil {
+		log.Printf("Failed updating ScriptRun status to %v: %s/%s", err, run.Namespace, run.Name)
+	}
+
+	pruneRuns(ctx, c, r, script)
+}
+
+// pruneRuns deletes the oldest ScriptRuns of a script beyond its history limits. Runs left
+// Running by executions started before the runner, i.e. by a previous instance of the
+// operator, are marked as failed first.
+func pruneRuns(ctx context.Context, c client.Client, r *runner, script scriptObject) {
+	runs := &scrv1.ScriptRunList{}
+	if err := c.List(ctx, runs, client.InNamespace(referenceNamespace(script)),
+		client.MatchingLabels{scrv1.ScriptRunScriptUIDLabel: string(script.GetUID())}); err != nil {
+		log.Printf("Failed listing ScriptRuns of %s: %v", fqn(script), err)
+		return
+	}
+
+	var succeeded, failed []*scrv1.ScriptRun
+	for i := range runs.Items {
+		run := &runs.Items[i]
+		if run.Status.Phase == scrv1.ScriptRunning && run.Status.StartTime != nil && r.startedBefore(run.Status.StartTime.Time) {
+			patch := client.MergeFrom(run.DeepCopy())
+			now := metav1.Now()
+			run.Status.Phase = scrv1.ScriptFailed
+			run.Status.CompletionTime = &now
+			run.Status.Error = &scrv1.ScriptError{Message: "execution was interrupted"}
+			if err := c.Status().Patch(ctx, run, patch); err != nil {
+				log.Printf("Failed updating ScriptRun status to %v: %s/%s", err, run.Namespace, run.Name)
+			}
+		}
+		switch run.Status.Phase {
+		case scrv1.ScriptSucceeded:
+			succeeded = append(succeeded, run)
+		case scrv1.ScriptFailed:
+			failed = append(failed, run)
+		}
+	}
+
+	spec := script.GetScriptSpec()
+	deleteOldestRuns(ctx, c, succeeded, historyLimit(spec.SuccessfulRunsHistoryLimit, defaultSuccessfulRunsHistoryLimit))
+	deleteOldestRuns(ctx, c, failed, historyLimit(spec.FailedRunsHistoryLimit, defaultFailedRunsHistoryLimit))
+}
+
+// deleteOldestRuns deletes all but the limit most recently started runs.
+func deleteOldestRuns(ctx context.Context, c client.Client, runs []*scrv1.ScriptRun, limit int) {
+	if len(runs) <= limit {
+		return
+	}
+	slices.SortFunc(runs, func(a, b *scrv1.ScriptRun) int {
+		return runStartTime(b).Compare(runStartTime(a).Time)
+	})
+	for _, run := range runs[limit:] {
+		if err := c.Delete(ctx, run); client.IgnoreNotFound(err) != nil {
+			log.Printf("Failed deleting ScriptRun %s/%s: %v", run.Namespace, run.Name, err)
+		}
+	}
+}
+
+// runStartTime returns the start time of a run, or its creation time if it has not been recorded.
+func runStartTime(run *scrv1.ScriptRun) metav1.Time {
+	if run.Status.StartTime != nil {
+		return *run.Status.StartTime
+	}
+	return run.CreationTimestamp
+}
+
+func historyLimit(limit *int32, def int) int {
+	if limit == nil {
+		return def
+	}
+	return int(*limit)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scrv1 "github.com/veith4f/scropt/api/v1"
+)
+
+// ScriptRunReconciler deletes ScriptRuns once their spec.ttlAfterFinished expired.
+// ScriptRuns are created and completed by the script reconcilers.
+type ScriptRunReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=scripts.scropt.io,resources=scriptruns,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=scripts.scropt.io,resources=scriptruns/status,verbs=get;update;patch
+
+// Reconcile deletes a finished ScriptRun whose TTL expired, or requeues it until then.
+func (r *ScriptRunReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	run := &scrv1.ScriptRun{}
+	if err := r.Get(ctx, req.NamespacedName, run); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	ttl := run.Spec.TTLAfterFinished
+	if ttl == nil || run.Status.CompletionTime == nil || run.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if remaining := time.Until(run.Status.CompletionTime.Add(ttl.Duration)); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+	log.Printf("Deleting expired ScriptRun: %s", fqn(run))
+	return ctrl.Result{}, client.IgnoreNotFound(r.Delete(ctx, run))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ScriptRunReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&scrv1.ScriptRun{}).
+		Named("scriptrun").
+		Complete(r)
+}
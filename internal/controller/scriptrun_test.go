@@ -0,0 +1,176 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+)
+
+var _ = Describe("Script runs", func() {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(scriptsv1.AddToScheme(scheme)).To(Succeed())
+
+	newClient := func(objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+			WithStatusSubresource(&scriptsv1.ScriptRun{}).Build()
+	}
+
+	newScript := func() *scriptsv1.LuaScript {
+		return &scriptsv1.LuaScript{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default", UID: "uid"}}
+	}
+
+	newRun := func(i int, phase scriptsv1.ScriptPhase, started time.Time) *scriptsv1.ScriptRun {
+		return &scriptsv1.ScriptRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("example-%d", i),
+				Namespace: "default",
+				Labels:    map[string]string{scriptsv1.ScriptRunScriptUIDLabel: "uid"},
+			},
+			Status: scriptsv1.ScriptRunStatus{Phase: phase, StartTime: &metav1.Time{Time: started}},
+		}
+	}
+
+	runNames := func(c client.Client) []string {
+		runs := &scriptsv1.ScriptRunList{}
+		Expect(c.List(ctx, runs)).To(Succeed())
+		var names []string
+		for _, run := range runs.Items {
+			names = append(names, run.Name)
+		}
+		return names
+	}
+
+	It("should record executions", func() {
+		c := newClient()
+		r := &runner{}
+		script := newScript()
+		script.Spec.RunTTL = &metav1.Duration{Duration: time.Hour}
+		setRunning(script, scriptCode{code: `print("hello")`})
+
+		run := startRun(ctx, c, r, script, "never executed")
+		Expect(run).NotTo(BeNil())
+		Expect(run.Name).To(HavePrefix("example-"))
+		Expect(run.Labels).To(HaveKeyWithValue(scriptsv1.ScriptRunScriptUIDLabel, "uid"))
+		Expect(run.OwnerReferences).To(HaveLen(1))
+		Expect(run.Spec.ScriptRef).To(Equal(scriptsv1.ScriptReference{
+			APIVersion: "scripts.scropt.io/v1", Kind: "LuaScript", Name: "example", Namespace: "default",
+		}))
+		Expect(run.Spec.TTLAfterFinished).To(Equal(script.Spec.RunTTL))
+		Expect(run.Status.Phase).To(Equal(scriptsv1.ScriptRunning))
+
+		output := &runOutput{}
+		_, _ = output.Write([]byte("hello\n"))
+		setCompleted(script, errors.New("failed"))
+		completeRun(ctx, c, r, run, script, output)
+
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(run), run)).To(Succeed())
+		Expect(run.Status.Phase).To(Equal(scriptsv1.ScriptFailed))
+		Expect(run.Status.Error.Message).To(Equal("failed"))
+		Expect(run.Status.Output).To(Equal("hello\n"))
+		Expect(run.Status.CompletionTime).NotTo(BeNil())
+	})
+
+	It("should record executions of scripts with long names", func() {
+		c := newClient()
+		script := newScript()
+		script.Name = strings.Repeat("a", 56) + "." + strings.Repeat("b", 5) + "-" + strings.Repeat("c", 20)
+		setRunning(script, scriptCode{code: `print("hello")`})
+
+		run := startRun(ctx, c, &runner{}, script, "never executed")
+		Expect(run).NotTo(BeNil())
+		Expect(validation.IsDNS1123Subdomain(run.Name)).To(BeEmpty())
+		Expect(run.Name).To(HavePrefix(strings.Repeat("a", 56) + "-"))
+		Expect(validation.IsValidLabelValue(run.Labels[scriptsv1.ScriptRunScriptLabel])).To(BeEmpty())
+		Expect(run.Labels).To(HaveKeyWithValue(scriptsv1.ScriptRunScriptLabel, strings.Repeat("a", 56)+"."+strings.Repeat("b", 5)))
+		Expect(run.Labels).To(HaveKeyWithValue(scriptsv1.ScriptRunScriptUIDLabel, "uid"))
+		Expect(run.Spec.ScriptRef.Name).To(Equal(script.Name))
+	})
+
+	It("should truncate large output", func() {
+		output := &runOutput{}
+		n, err := output.Write([]byte(strings.Repeat("x", maxRunOutput+1)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(maxRunOutput + 1))
+		Expect(output.buf).To(HaveLen(maxRunOutput))
+		Expect(output.truncated).To(BeTrue())
+	})
+
+	It("should keep the most recent runs within the history limits", func() {
+		now := time.Now()
+		var objs []client.Object
+		for i := range 5 {
+			objs = append(objs, newRun(i, scriptsv1.ScriptSucceeded, now.Add(time.Duration(i)*time.Minute)))
+		}
+		objs = append(objs, newRun(5, scriptsv1.ScriptFailed, now), newRun(6, scriptsv1.ScriptFailed, now.Add(time.Minute)))
+		c := newClient(objs...)
+
+		script := newScript()
+		script.Spec.FailedRunsHistoryLimit = ptr.To[int32](0)
+		pruneRuns(ctx, c, &runner{}, script)
+		Expect(runNames(c)).To(ConsistOf("example-2", "example-3", "example-4"))
+	})
+
+	It("should fail runs interrupted by a restart of the operator", func() {
+		c := newClient(newRun(0, scriptsv1.ScriptRunning, time.Now().Add(-time.Hour)))
+		pruneRuns(ctx, c, &runner{}, newScript())
+
+		run := &scriptsv1.ScriptRun{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "example-0"}, run)).To(Succeed())
+		Expect(run.Status.Phase).To(Equal(scriptsv1.ScriptFailed))
+		Expect(run.Status.Error.Message).To(Equal("execution was interrupted"))
+	})
+
+	It("should delete runs once their TTL expired", func() {
+		expired := newRun(0, scriptsv1.ScriptSucceeded, time.Now().Add(-time.Hour))
+		expired.Spec.TTLAfterFinished = &metav1.Duration{Duration: time.Minute}
+		expired.Status.CompletionTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+		pending := expired.DeepCopy()
+		pending.Name = "example-1"
+		pending.Spec.TTLAfterFinished = &metav1.Duration{Duration: 2 * time.Hour}
+		c := newClient(expired, pending)
+		r := &ScriptRunReconciler{Client: c, Scheme: scheme}
+
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(expired)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pending)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		Expect(runNames(c)).To(ConsistOf("example-1"))
+	})
+})
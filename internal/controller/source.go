@@ -0,0 +1,174 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Field indexes of scripts by the names of the ConfigMaps and Secrets their code and args are loaded from.
+const (
+	configMapSourceIndex = ".spec.source.configMapKeyRef.name"
+	secretSourceIndex    = ".spec.source.secretKeyRef.name"
+)
+
+// scriptCode is the code of a script resolved from spec.code or spec.source.
+type scriptCode struct {
+	code string
+	// revision of the ConfigMap or Secret the code was loaded from, if any
+	revision string
+}
+
+// errSourceNotFound is returned by resolveCode if the referenced ConfigMap,
+// Secret or key does not exist and the reference is not optional.
+type errSourceNotFound struct {
+	msg string
+}
+
+func (e *errSourceNotFound) Error() string {
+	return e.msg
+}
+
+// resolveCode loads the code of a script from wherever its spec refers to.
+// Code of optional references that do not exist is empty.
+func resolveCode(ctx context.Context, c client.Client, script scriptObject) (scriptCode, error) {
+	spec := script.GetScriptSpec()
+	src := spec.Source
+	switch {
+	case src == nil:
+		return scriptCode{code: spec.Code}, nil
+
+	case src.ConfigMapKeyRef != nil:
+		ref := src.ConfigMapKeyRef
+		cm := &corev1.ConfigMap{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: referenceNamespace(script), Name: ref.Name}, cm); err != nil {
+			return sourceNotFound(err, ref.Optional, "ConfigMap %s not found", ref.Name)
+		}
+		code, ok := cm.Data[ref.Key]
+		if binary, found := cm.BinaryData[ref.Key]; !ok && found {
+			code, ok = string(binary), true
+		}
+		if !ok {
+			return sourceNotFound(nil, ref.Optional, "key %s not found in ConfigMap %s", ref.Key, ref.Name)
+		}
+		return scriptCode{code: code, revision: revision("ConfigMap", cm)}, nil
+
+	case src.SecretKeyRef != nil:
+		ref := src.SecretKeyRef
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: referenceNamespace(script), Name: ref.Name}, secret); err != nil {
+			return sourceNotFound(err, ref.Optional, "Secret %s not found", ref.Name)
+		}
+		code, ok := secret.Data[ref.Key]
+		if !ok {
+			return sourceNotFound(nil, ref.Optional, "key %s not found in Secret %s", ref.Key, ref.Name)
+		}
+		return scriptCode{code: string(code), revision: revision("Secret", secret)}, nil
+
+	default:
+		return scriptCode{code: src.Inline}, nil
+	}
+}
+
+// sourceNotFound returns the result of resolveCode for a reference that could not be resolved.
+// Errors other than NotFound are returned as is, so that they are retried.
+func sourceNotFound(err error, optional *bool, format string, args ...any) (scriptCode, error) {
+	if err != nil && !apierrors.IsNotFound(err) {
+		return scriptCode{}, err
+	}
+	if optional != nil && *optional {
+		return scriptCode{}, nil
+	}
+	return scriptCode{}, &errSourceNotFound{msg: fmt.Sprintf(format, args...)}
+}
+
+// revision identifies the version of the object code was loaded from.
+func revision(kind string, obj client.Object) string {
+	return fmt.Sprintf("%s/%s@%s", kind, obj.GetName(), obj.GetResourceVersion())
+}
+
+// indexSources registers the field indexes of a script kind used by enqueueForSource.
+func indexSources(ctx context.Context, mgr ctrl.Manager, script scriptObject) error {
+	if err := mgr.GetFieldIndexer().IndexField(ctx, script, configMapSourceIndex, func(obj client.Object) []string {
+		var names []string
+		spec := obj.(scriptObject).GetScriptSpec()
+		if spec.Source != nil && spec.Source.ConfigMapKeyRef != nil {
+			names = append(names, spec.Source.ConfigMapKeyRef.Name)
+		}
+		for _, arg := range spec.Args {
+			if arg.ValueFrom != nil && arg.ValueFrom.ConfigMapKeyRef != nil {
+				names = append(names, arg.ValueFrom.ConfigMapKeyRef.Name)
+			}
+		}
+		return names
+	}); err != nil {
+		return err
+	}
+	return mgr.GetFieldIndexer().IndexField(ctx, script, secretSourceIndex, func(obj client.Object) []string {
+		var names []string
+		spec := obj.(scriptObject).GetScriptSpec()
+		if spec.Source != nil && spec.Source.SecretKeyRef != nil {
+			names = append(names, spec.Source.SecretKeyRef.Name)
+		}
+		for _, arg := range spec.Args {
+			if arg.ValueFrom != nil && arg.ValueFrom.SecretKeyRef != nil {
+				names = append(names, arg.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+		return names
+	})
+}
+
+// enqueueForSource enqueues the scripts of the kind of list whose code or args are
+// loaded from a changed ConfigMap or Secret, as found through index. Cluster-scoped
+// scripts only refer to ConfigMaps and Secrets in the namespace of the operator.
+func enqueueForSource(c client.Client, list client.ObjectList, index string, clusterScoped bool) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		opts := []client.ListOption{client.MatchingFields{index: obj.GetName()}}
+		if !clusterScoped {
+			opts = append(opts, client.InNamespace(obj.GetNamespace()))
+		} else if obj.GetNamespace() != operatorNamespace() {
+			return nil
+		}
+		scripts := list.DeepCopyObject().(client.ObjectList)
+		if err := c.List(ctx, scripts, opts...); err != nil {
+			log.Printf("Failed listing scripts referring to %s: %v", fqn(obj), err)
+			return nil
+		}
+		items, err := meta.ExtractList(scripts)
+		if err != nil {
+			log.Printf("Failed listing scripts referring to %s: %v", fqn(obj), err)
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(items))
+		for _, item := range items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(item.(client.Object))})
+		}
+		return requests
+	})
+}
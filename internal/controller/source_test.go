@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+)
+
+var _ = Describe("Script source", func() {
+	ctx := context.Background()
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "code", Namespace: "default"},
+		Data:       map[string]string{"main.lua": `print("hello")`},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "code", Namespace: "default"},
+		Data:       map[string][]byte{"main.lua": []byte(`print("secret")`)},
+	}
+	c := fake.NewClientBuilder().WithObjects(cm, secret).Build()
+
+	newScript := func(source *scriptsv1.ScriptSource) *scriptsv1.LuaScript {
+		script := &scriptsv1.LuaScript{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}
+		script.Spec.Source = source
+		return script
+	}
+
+	It("should load inline code", func() {
+		code, err := resolveCode(ctx, c, newScript(&scriptsv1.ScriptSource{Inline: `print("inline")`}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(code.code).To(Equal(`print("inline")`))
+		Expect(code.revision).To(BeEmpty())
+	})
+
+	It("should load code from ConfigMaps", func() {
+		code, err := resolveCode(ctx, c, newScript(&scriptsv1.ScriptSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "code"},
+			Key:                  "main.lua",
+		}}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(code.code).To(Equal(`print("hello")`))
+		Expect(code.revision).To(HavePrefix("ConfigMap/code@"))
+	})
+
+	It("should load code from Secrets", func() {
+		code, err := resolveCode(ctx, c, newScript(&scriptsv1.ScriptSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "code"},
+			Key:                  "main.lua",
+		}}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(code.code).To(Equal(`print("secret")`))
+	})
+
+	It("should load code of cluster-scoped scripts from the operator namespace", func() {
+		script := &scriptsv1.ClusterLuaScript{ObjectMeta: metav1.ObjectMeta{Name: "example"}}
+		script.Spec.Source = &scriptsv1.ScriptSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "code"},
+			Key:                  "main.lua",
+		}}
+		Expect(operatorNamespace()).To(Equal("default"))
+		code, err := resolveCode(ctx, c, script)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(code.code).To(Equal(`print("hello")`))
+	})
+
+	It("should report missing keys", func() {
+		_, err := resolveCode(ctx, c, newScript(&scriptsv1.ScriptSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "code"},
+			Key:                  "missing.lua",
+		}}))
+		var notFound *errSourceNotFound
+		Expect(err).To(BeAssignableToTypeOf(notFound))
+	})
+
+	It("should resolve missing optional references to empty code", func() {
+		code, err := resolveCode(ctx, c, newScript(&scriptsv1.ScriptSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "missing"},
+			Key:                  "main.lua",
+			Optional:             ptr.To(true),
+		}}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(code.code).To(BeEmpty())
+	})
+})
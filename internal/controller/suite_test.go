@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	scriptsv1 "github.com/veith4f/scropt/api/v1"
+	scriptsv2 "github.com/veith4f/scropt/api/v2"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -61,6 +62,8 @@ var _ = BeforeSuite(func() {
 	var err error
 	err = scriptsv1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
+	err = scriptsv2.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
 
 	// +kubebuilder:scaffold:scheme
 
@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"log"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	scrv1 "github.com/veith4f/scropt/api/v1"
+)
+
+// pausedKey of the operator ConfigMap pauses all scripts if set to "true".
+const pausedKey = "paused"
+
+// suspension is why a script must not be executed.
+type suspension struct {
+	reason  string
+	message string
+}
+
+// suspension returns why a script must not be executed, either because of its
+// spec.suspend, because it is being migrated or because the operator ConfigMap
+// pauses all scripts, or nil.
+func (r *runner) suspension(ctx context.Context, c client.Client, script scriptObject) (*suspension, error) {
+	if script.GetScriptSpec().Suspend {
+		return &suspension{reason: scrv1.ReasonSuspended, message: "Script is suspended"}, nil
+	}
+	if _, ok := script.GetAnnotations()[scrv1.MigratingAnnotation]; ok {
+		return &suspension{reason: scrv1.ReasonMigrating, message: "Script is being migrated to a v2 Script"}, nil
+	}
+	if r.configMap == "" {
+		return nil, nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: operatorNamespace(), Name: r.configMap}, cm); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if cm.Data[pausedKey] == "true" {
+		return &suspension{reason: scrv1.ReasonPaused, message: "All scripts are paused by ConfigMap " + r.configMap}, nil
+	}
+	return nil, nil
+}
+
+// setSuspended marks a script that is not executed because of a suspension.
+func setSuspended(script scriptObject, s *suspension) {
+	status := script.GetScriptStatus()
+	status.Phase = scrv1.ScriptSuspended
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scrv1.ConditionSuspended,
+		Status:             metav1.ConditionTrue,
+		Reason:             s.reason,
+		Message:            s.message,
+		ObservedGeneration: script.GetGeneration(),
+	})
+}
+
+// setResumed restores the phase of a script that was suspended from the outcome of its last execution.
+func setResumed(script scriptObject) {
+	status := script.GetScriptStatus()
+	if !meta.IsStatusConditionTrue(status.Conditions, scrv1.ConditionSuspended) {
+		return
+	}
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scrv1.ConditionSuspended,
+		Status:             metav1.ConditionFalse,
+		Reason:             scrv1.ReasonResumed,
+		Message:            "Script is not suspended",
+		ObservedGeneration: script.GetGeneration(),
+	})
+	if status.Phase != scrv1.ScriptSuspended {
+		return
+	}
+	status.Phase = completedPhase(status)
+}
+
+// completedPhase returns the phase of a script from the outcome of its last execution.
+func completedPhase(status *scrv1.ScriptStatus) scrv1.ScriptPhase {
+	switch cond := meta.FindStatusCondition(status.Conditions, scrv1.ConditionSucceeded); {
+	case cond == nil || cond.Status == metav1.ConditionUnknown:
+		return scrv1.ScriptPending
+	case cond.Status == metav1.ConditionTrue:
+		return scrv1.ScriptSucceeded
+	default:
+		return scrv1.ScriptFailed
+	}
+}
+
+// enqueueForConfigMap enqueues all scripts of the kind of list when the operator ConfigMap
+// named name changes, so that they are suspended or resumed.
+func enqueueForConfigMap(c client.Client, list client.ObjectList, name string) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		if name == "" || obj.GetName() != name || obj.GetNamespace() != operatorNamespace() {
+			return nil
+		}
+		scripts := list.DeepCopyObject().(client.ObjectList)
+		if err := c.List(ctx, scripts); err != nil {
+			log.Printf("Failed listing scripts for ConfigMap %s: %v", fqn(obj), err)
+			return nil
+		}
+		items, err := meta.ExtractList(scripts)
+		if err != nil {
+			log.Printf("Failed listing scripts for ConfigMap %s: %v", fqn(obj), err)
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(items))
+		for _, item := range items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(item.(client.Object))})
+		}
+		return requests
+	})
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+)
+
+var _ = Describe("Script suspension", func() {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(scriptsv1.AddToScheme(scheme)).To(Succeed())
+
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "scropt-config", Namespace: "default"},
+		Data:       map[string]string{pausedKey: "true"},
+	}
+
+	newScript := func() *scriptsv1.LuaScript {
+		return &scriptsv1.LuaScript{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default", Generation: 1}}
+	}
+
+	It("should suspend scripts by spec and by the operator ConfigMap", func() {
+		c := fake.NewClientBuilder().WithObjects(config).Build()
+		script := newScript()
+
+		suspended, err := (&runner{}).suspension(ctx, c, script)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(suspended).To(BeNil())
+
+		suspended, err = (&runner{configMap: "scropt-config"}).suspension(ctx, c, script)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(suspended.reason).To(Equal(scriptsv1.ReasonPaused))
+
+		suspended, err = (&runner{configMap: "missing"}).suspension(ctx, c, script)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(suspended).To(BeNil())
+
+		script.Spec.Suspend = true
+		suspended, err = (&runner{}).suspension(ctx, c, script)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(suspended.reason).To(Equal(scriptsv1.ReasonSuspended))
+	})
+
+	It("should restore the phase of the last execution when resumed", func() {
+		script := newScript()
+		setCompleted(script, nil)
+		setSuspended(script, &suspension{reason: scriptsv1.ReasonSuspended, message: "Script is suspended"})
+		Expect(script.Status.Phase).To(Equal(scriptsv1.ScriptSuspended))
+		Expect(meta.IsStatusConditionTrue(script.Status.Conditions, scriptsv1.ConditionSuspended)).To(BeTrue())
+
+		setResumed(script)
+		Expect(script.Status.Phase).To(Equal(scriptsv1.ScriptSucceeded))
+		Expect(meta.IsStatusConditionFalse(script.Status.Conditions, scriptsv1.ConditionSuspended)).To(BeTrue())
+	})
+
+	It("should not execute suspended scripts", func() {
+		script := newScript()
+		script.Spec.Suspend = true
+		script.Spec.Code = `error("must not run")`
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(script).WithStatusSubresource(script).Build()
+		r := &runner{}
+
+		_, err := reconcileScript(ctx, c, r, &triggers{}, script, "LuaScript", compileLua)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.active(script)).To(BeZero())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(script), script)).To(Succeed())
+		Expect(script.Status.Phase).To(Equal(scriptsv1.ScriptSuspended))
+		Expect(script.Status.StartTime).To(BeNil())
+
+		script.Spec.Suspend = false
+		script.Spec.Code = ""
+		Expect(c.Update(ctx, script)).To(Succeed())
+		_, err = reconcileScript(ctx, c, r, &triggers{}, script, "LuaScript", compileLua)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(script), script)).To(Succeed())
+		Expect(script.Status.Phase).To(Equal(scriptsv1.ScriptPending))
+		Expect(meta.IsStatusConditionFalse(script.Status.Conditions, scriptsv1.ConditionSuspended)).To(BeTrue())
+	})
+})
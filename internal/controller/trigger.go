@@ -0,0 +1,334 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	scrv1 "github.com/veith4f/scropt/api/v1"
+)
+
+// triggerQueueSize is the number of events buffered per script.
+// Events beyond are dropped while the script is busy.
+const triggerQueueSize = 1024
+
+// triggerEvent is a change to a watched object, as exposed to scripts.
+type triggerEvent struct {
+	Type      scrv1.TriggerEventType
+	Object    *unstructured.Unstructured
+	OldObject *unstructured.Unstructured
+}
+
+// global returns the event as value of the "event" global of a script.
+func (e triggerEvent) global() map[string]any {
+	global := map[string]any{"type": string(e.Type), "object": e.Object.Object}
+	if e.OldObject != nil {
+		global["oldObject"] = e.OldObject.Object
+	}
+	return global
+}
+
+// triggerFunc executes the script identified by key for an event.
+// It returns once the execution finished.
+type triggerFunc func(ctx context.Context, key types.NamespacedName, ev triggerEvent)
+
+// triggers watches the objects selected by the triggers of scripts and
+// executes the scripts for every change. Events are processed one at a time
+// per script, in the order they were received.
+type triggers struct {
+	mu         sync.Mutex
+	controller controller.Controller
+	cache      cache.Cache
+	mapper     meta.RESTMapper
+	run        triggerFunc
+	// watches that have been started, they are shared by all scripts and cannot be stopped
+	watches map[schema.GroupVersionKind]struct{}
+	// scripts with triggers
+	scripts map[types.NamespacedName]*scriptTriggers
+}
+
+// scriptTriggers are the triggers of a single script.
+type scriptTriggers struct {
+	generation int64
+	triggers   []*trigger
+	// objects created before are reported by the initial list of a watch, they do not trigger
+	since  time.Time
+	events chan triggerEvent
+	cancel context.CancelFunc
+}
+
+// trigger is a TriggerSpec resolved against the script and the API server.
+type trigger struct {
+	gvk schema.GroupVersionKind
+	// namespace of the watched objects or empty for all namespaces
+	namespace string
+	labels    labels.Selector
+	fields    fields.Selector
+	events    []scrv1.TriggerEventType
+}
+
+// setup prepares the triggers for watching through the controller of a reconciler.
+func (t *triggers) setup(mgr ctrl.Manager, c controller.Controller, run triggerFunc) {
+	t.controller = c
+	t.cache = mgr.GetCache()
+	t.mapper = mgr.GetRESTMapper()
+	t.run = run
+	t.watches = make(map[schema.GroupVersionKind]struct{})
+	t.scripts = make(map[types.NamespacedName]*scriptTriggers)
+}
+
+// update registers the triggers of a script, starting watches as necessary.
+// It returns an error if any trigger is invalid, the previous triggers of the
+// script remain in effect in that case.
+func (t *triggers) update(ctx context.Context, script scriptObject) error {
+	if t.controller == nil {
+		// not set up with a manager, e.g. in tests
+		return nil
+	}
+
+	key := client.ObjectKeyFromObject(script)
+	specs := script.GetScriptSpec().Triggers
+	if len(specs) == 0 || script.GetDeletionTimestamp() != nil {
+		t.remove(key)
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	registered := t.scripts[key]
+	if registered != nil && registered.generation == script.GetGeneration() {
+		return nil
+	}
+
+	resolved, err := resolveTriggers(t.mapper, specs, script.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	for _, trig := range resolved {
+		if err := t.watch(trig.gvk); err != nil {
+			return fmt.Errorf("failed watching %s: %w", trig.gvk, err)
+		}
+	}
+
+	if registered == nil {
+		workerCtx, cancel := context.WithCancel(ctx)
+		registered = &scriptTriggers{
+			since:  time.Now().Truncate(time.Second),
+			events: make(chan triggerEvent, triggerQueueSize),
+			cancel: cancel,
+		}
+		t.scripts[key] = registered
+		go t.work(workerCtx, key, registered.events)
+	}
+	registered.generation = script.GetGeneration()
+	registered.triggers = resolved
+	return nil
+}
+
+// remove unregisters the triggers of a script and drops its pending events.
+func (t *triggers) remove(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if registered, ok := t.scripts[key]; ok {
+		registered.cancel()
+		delete(t.scripts, key)
+	}
+}
+
+// watch starts watching objects of a kind unless already watched.
+func (t *triggers) watch(gvk schema.GroupVersionKind) error {
+	if _, ok := t.watches[gvk]; ok {
+		return nil
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	h := handler.TypedFuncs[*unstructured.Unstructured, reconcile.Request]{
+		CreateFunc: func(_ context.Context, e event.TypedCreateEvent[*unstructured.Unstructured], _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			t.dispatch(gvk, triggerEvent{Type: scrv1.TriggerAdded, Object: e.Object})
+		},
+		UpdateFunc: func(_ context.Context, e event.TypedUpdateEvent[*unstructured.Unstructured], _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			t.dispatch(gvk, triggerEvent{Type: scrv1.TriggerModified, Object: e.ObjectNew, OldObject: e.ObjectOld})
+		},
+		DeleteFunc: func(_ context.Context, e event.TypedDeleteEvent[*unstructured.Unstructured], _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			t.dispatch(gvk, triggerEvent{Type: scrv1.TriggerDeleted, Object: e.Object})
+		},
+	}
+	if err := t.controller.Watch(source.Kind(t.cache, obj, h)); err != nil {
+		return err
+	}
+	t.watches[gvk] = struct{}{}
+	return nil
+}
+
+// dispatch queues an event for every script with a matching trigger.
+func (t *triggers) dispatch(gvk schema.GroupVersionKind, ev triggerEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, registered := range t.scripts {
+		if ev.Type == scrv1.TriggerAdded && ev.Object.GetCreationTimestamp().Time.Before(registered.since) {
+			continue
+		}
+		if !slices.ContainsFunc(registered.triggers, func(trig *trigger) bool {
+			return trig.gvk == gvk && trig.matches(ev)
+		}) {
+			continue
+		}
+		select {
+		case registered.events <- ev:
+		default:
+			log.Printf("Dropping %s event of %s %s for busy script: %s", ev.Type, gvk.Kind, fqn(ev.Object), key)
+		}
+	}
+}
+
+// work executes a script for its events one at a time until ctx is cancelled.
+func (t *triggers) work(ctx context.Context, key types.NamespacedName, events <-chan triggerEvent) {
+	for {
+		select {
+		case ev := <-events:
+			t.run(ctx, key, ev)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// resolveTriggers resolves the TriggerSpecs of a script in namespace scriptNamespace,
+// empty for cluster-scoped scripts, against the kinds known to mapper.
+// Cluster-scoped kinds cannot be watched by namespaced scripts, see newTrigger.
+func resolveTriggers(mapper meta.RESTMapper, specs []scrv1.TriggerSpec, scriptNamespace string) ([]*trigger, error) {
+	resolved := make([]*trigger, 0, len(specs))
+	for i, spec := range specs {
+		trig, err := newTrigger(spec, scriptNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("trigger %d: %w", i, err)
+		}
+		mapping, err := mapper.RESTMapping(trig.gvk.GroupKind(), trig.gvk.Version)
+		if err != nil {
+			return nil, fmt.Errorf("trigger %d: unknown kind %s %s: %w", i, spec.APIVersion, spec.Kind, err)
+		}
+		if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+			if scriptNamespace != "" {
+				return nil, fmt.Errorf("trigger %d: kind %s is cluster-scoped, namespaced scripts can only watch objects in their own namespace", i, spec.Kind)
+			}
+			trig.namespace = ""
+		}
+		resolved = append(resolved, trig)
+	}
+	return resolved, nil
+}
+
+// newTrigger resolves a TriggerSpec of a script in namespace scriptNamespace,
+// empty for cluster-scoped scripts. The watched kind is assumed to be namespaced.
+// Watches go through the cache of the operator rather than the service account
+// of the script, so namespaced scripts may only watch their own namespace.
+func newTrigger(spec scrv1.TriggerSpec, scriptNamespace string) (*trigger, error) {
+	gv, err := schema.ParseGroupVersion(spec.APIVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apiVersion %q: %w", spec.APIVersion, err)
+	}
+
+	trig := &trigger{
+		gvk:    gv.WithKind(spec.Kind),
+		labels: labels.Everything(),
+		fields: fields.Everything(),
+		events: spec.Events,
+	}
+
+	if scriptNamespace != "" && spec.Namespace != "" && spec.Namespace != scriptNamespace {
+		return nil, fmt.Errorf("namespace %q: namespaced scripts can only watch objects in their own namespace", spec.Namespace)
+	}
+
+	switch spec.Namespace {
+	case "":
+		trig.namespace = scriptNamespace
+	case "*":
+		trig.namespace = ""
+	default:
+		trig.namespace = spec.Namespace
+	}
+
+	if spec.LabelSelector != nil {
+		if trig.labels, err = metav1.LabelSelectorAsSelector(spec.LabelSelector); err != nil {
+			return nil, fmt.Errorf("invalid labelSelector: %w", err)
+		}
+	}
+	if spec.FieldSelector != "" {
+		if trig.fields, err = fields.ParseSelector(spec.FieldSelector); err != nil {
+			return nil, fmt.Errorf("invalid fieldSelector %q: %w", spec.FieldSelector, err)
+		}
+	}
+	return trig, nil
+}
+
+// matches returns true if the trigger selects the object of an event.
+// The kind of the object is not checked.
+func (trig *trigger) matches(ev triggerEvent) bool {
+	if len(trig.events) > 0 && !slices.Contains(trig.events, ev.Type) {
+		return false
+	}
+	obj := ev.Object
+	if trig.namespace != "" && obj.GetNamespace() != trig.namespace {
+		return false
+	}
+	if !trig.labels.Matches(labels.Set(obj.GetLabels())) {
+		return false
+	}
+	if trig.fields.Empty() {
+		return true
+	}
+
+	// field selectors may refer to any field, missing fields are empty
+	values := fields.Set{}
+	for _, req := range trig.fields.Requirements() {
+		value, found, err := unstructured.NestedFieldNoCopy(obj.Object, strings.Split(req.Field, ".")...)
+		if err == nil && found && value != nil {
+			values[req.Field] = fmt.Sprint(value)
+		} else {
+			values[req.Field] = ""
+		}
+	}
+	return trig.fields.Matches(values)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+)
+
+var _ = Describe("Script trigger", func() {
+	newEvent := func(eventType scriptsv1.TriggerEventType, namespace string, labels map[string]string, phase string) triggerEvent {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"status":     map[string]any{"phase": phase},
+		}}
+		obj.SetName("example")
+		obj.SetNamespace(namespace)
+		obj.SetLabels(labels)
+		return triggerEvent{Type: eventType, Object: obj}
+	}
+
+	newTestTrigger := func(spec scriptsv1.TriggerSpec) *trigger {
+		spec.APIVersion, spec.Kind = "v1", "Pod"
+		trig, err := newTrigger(spec, "default")
+		Expect(err).NotTo(HaveOccurred())
+		return trig
+	}
+
+	It("should select objects in the namespace of the script by default", func() {
+		trig := newTestTrigger(scriptsv1.TriggerSpec{})
+		Expect(trig.matches(newEvent(scriptsv1.TriggerAdded, "default", nil, ""))).To(BeTrue())
+		Expect(trig.matches(newEvent(scriptsv1.TriggerAdded, "other", nil, ""))).To(BeFalse())
+	})
+
+	It("should select objects in all namespaces for cluster-scoped scripts", func() {
+		trig, err := newTrigger(scriptsv1.TriggerSpec{APIVersion: "v1", Kind: "Pod", Namespace: "*"}, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trig.matches(newEvent(scriptsv1.TriggerAdded, "other", nil, ""))).To(BeTrue())
+	})
+
+	It("should restrict namespaced scripts to their own namespace", func() {
+		_, err := newTrigger(scriptsv1.TriggerSpec{APIVersion: "v1", Kind: "Secret", Namespace: "*"}, "default")
+		Expect(err).To(MatchError(ContainSubstring("own namespace")))
+		_, err = newTrigger(scriptsv1.TriggerSpec{APIVersion: "v1", Kind: "Secret", Namespace: "other"}, "default")
+		Expect(err).To(MatchError(ContainSubstring("own namespace")))
+		trig := newTestTrigger(scriptsv1.TriggerSpec{Namespace: "default"})
+		Expect(trig.matches(newEvent(scriptsv1.TriggerAdded, "default", nil, ""))).To(BeTrue())
+	})
+
+	It("should restrict namespaced scripts to namespaced kinds", func() {
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+		mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Node"}, meta.RESTScopeRoot)
+		nodes := []scriptsv1.TriggerSpec{{APIVersion: "v1", Kind: "Node"}}
+
+		_, err := resolveTriggers(mapper, nodes, "default")
+		Expect(err).To(MatchError(ContainSubstring("kind Node is cluster-scoped")))
+
+		resolved, err := resolveTriggers(mapper, nodes, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resolved[0].namespace).To(BeEmpty())
+
+		resolved, err = resolveTriggers(mapper, []scriptsv1.TriggerSpec{{APIVersion: "v1", Kind: "Pod"}}, "default")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resolved[0].namespace).To(Equal("default"))
+	})
+
+	It("should select objects by labels", func() {
+		trig := newTestTrigger(scriptsv1.TriggerSpec{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "example"}},
+		})
+		Expect(trig.matches(newEvent(scriptsv1.TriggerAdded, "default", map[string]string{"app": "example"}, ""))).To(BeTrue())
+		Expect(trig.matches(newEvent(scriptsv1.TriggerAdded, "default", map[string]string{"app": "other"}, ""))).To(BeFalse())
+	})
+
+	It("should select objects by arbitrary fields", func() {
+		trig := newTestTrigger(scriptsv1.TriggerSpec{FieldSelector: "metadata.name=example,status.phase!=Running"})
+		Expect(trig.matches(newEvent(scriptsv1.TriggerModified, "default", nil, "Pending"))).To(BeTrue())
+		Expect(trig.matches(newEvent(scriptsv1.TriggerModified, "default", nil, "Running"))).To(BeFalse())
+	})
+
+	It("should select only the given event types", func() {
+		trig := newTestTrigger(scriptsv1.TriggerSpec{Events: []scriptsv1.TriggerEventType{scriptsv1.TriggerDeleted}})
+		Expect(trig.matches(newEvent(scriptsv1.TriggerDeleted, "default", nil, ""))).To(BeTrue())
+		Expect(trig.matches(newEvent(scriptsv1.TriggerModified, "default", nil, ""))).To(BeFalse())
+	})
+
+	It("should reject invalid selectors", func() {
+		_, err := newTrigger(scriptsv1.TriggerSpec{APIVersion: "v1", Kind: "Pod", FieldSelector: "status.phase"}, "default")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should expose the previous object of modifications", func() {
+		ev := newEvent(scriptsv1.TriggerModified, "default", nil, "Running")
+		ev.OldObject = newEvent(scriptsv1.TriggerAdded, "default", nil, "Pending").Object
+		global := ev.global()
+		Expect(global).To(HaveKeyWithValue("type", "Modified"))
+		Expect(global["oldObject"]).To(HaveKeyWithValue("status", map[string]any{"phase": "Pending"}))
+	})
+})
@@ -1,9 +1,48 @@
 package controller
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"strings"
+	"sync"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func fqn(script metav1.ObjectMeta) string {
-	return script.Namespace + "/" + script.Name
+// serviceAccountNamespaceFile holds the namespace of the pod when running in a cluster.
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// operatorNamespace returns the namespace the operator runs in, as set by the
+// POD_NAMESPACE environment variable or read from the service account of the pod.
+// It defaults to "default" when running outside of a cluster.
+var operatorNamespace = sync.OnceValue(func() string {
+	if ns := os.Getenv("POD_NAMESPACE"); ns != "" {
+		return ns
+	}
+	if ns, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+		return strings.TrimSpace(string(ns))
+	}
+	return "default"
+})
+
+// referenceNamespace returns the namespace of the ConfigMaps and Secrets a script
+// refers to, which is the namespace of the operator for cluster-scoped scripts.
+func referenceNamespace(script metav1.Object) string {
+	if script.GetNamespace() == "" {
+		return operatorNamespace()
+	}
+	return script.GetNamespace()
+}
+
+func fqn(script metav1.Object) string {
+	if script.GetNamespace() == "" {
+		return script.GetName()
+	}
+	return script.GetNamespace() + "/" + script.GetName()
+}
+
+func hashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
 }
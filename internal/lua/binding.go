@@ -3,50 +3,36 @@ package lua
 import (
 	"errors"
 	"fmt"
-	"go/types"
 	"reflect"
 
 	lua "github.com/yuin/gopher-lua"
-	"golang.org/x/tools/go/packages"
 )
 
-// Detect if a Lua table is an array
-func isArrayTable(tbl *lua.LTable) bool {
-	var hasNonIntegerKey bool
-	tbl.ForEach(func(key lua.LValue, _ lua.LValue) {
-		if _, ok := key.(lua.LNumber); !ok {
-			hasNonIntegerKey = true
-		}
-	})
-	return !hasNonIntegerKey
-}
-
 func addFunction(L *lua.LState, namespace *lua.LTable, name string, fn reflect.Value) {
-	handler := func(L *lua.LState) int {
-		args := make([]reflect.Value, L.GetTop())
-		for i := 1; i <= len(args); i++ {
-			args[i-1] = reflect.ValueOf(luaValToGo(L.CheckAny(i)))
-		}
+	if namespace == nil {
+		L.SetGlobal(name, newFunction(L, fn))
+	} else {
+		L.SetField(namespace, name, newFunction(L, fn))
+	}
+}
 
-		results := fn.Call(args)
+// newFunction wraps a Go function to be called from Lua.
+func newFunction(L *lua.LState, fn reflect.Value) *lua.LFunction {
+	return L.NewFunction(func(L *lua.LState) int {
+		results := fn.Call(callArgs(L, fn.Type()))
 		nresults := len(results)
 
 		for i := 0; i < nresults; i++ {
 			L.Push(goValToLua(L, results[i]))
 		}
 		return len(results)
-	}
-	if namespace == nil {
-		L.SetGlobal(name, L.NewFunction(handler))
-	} else {
-		L.SetField(namespace, name, L.NewFunction(handler))
-	}
+	})
 }
 
-func addType(L *lua.LState, namespace *lua.LTable, typ reflect.Type) {
+func addType(L *lua.LState, namespace *lua.LTable, name string, typ reflect.Type) {
 	// Create a new table to represent the class
 	class := L.NewTable()
-	L.SetField(namespace, typ.Name(), class)
+	L.SetField(namespace, name, class)
 
 	// Define the "get" method to return a table containing all fields
 	L.SetField(class, "get", L.NewFunction(func(L *lua.LState) int {
@@ -78,7 +64,6 @@ func addType(L *lua.LState, namespace *lua.LTable, typ reflect.Type) {
 			field := structType.Field(i)
 			fieldValue := structValue.Field(i)
 			if fieldValue.CanInterface() {
-				fmt.Printf("returned map has field: %s\n", field.Name)
 				result.RawSetString(field.Name, goValToLua(L, fieldValue))
 			}
 		}
@@ -91,8 +76,8 @@ func addType(L *lua.LState, namespace *lua.LTable, typ reflect.Type) {
 	// Define the "set" method to update fields from a Lua table
 	L.SetField(class, "set", L.NewFunction(func(L *lua.LState) int {
 		// Get the instance (self)
-		self := L.CheckUserData(1)
-		if self == nil {
+		self := boundValue(L.Get(1))
+		if !self.IsValid() || self.Kind() != reflect.Ptr || self.IsNil() {
 			L.RaiseError("Invalid object")
 			return 0
 		}
@@ -105,7 +90,7 @@ func addType(L *lua.LState, namespace *lua.LTable, typ reflect.Type) {
 		}
 
 		// Get the underlying Go struct value
-		structValue := reflect.ValueOf(self.Value).Elem()
+		structValue := self.Elem()
 
 		// Iterate over struct fields and update them if present in the table
 		luaTable.ForEach(func(key, value lua.LValue) {
@@ -114,28 +99,11 @@ func addType(L *lua.LState, namespace *lua.LTable, typ reflect.Type) {
 				field := structValue.FieldByName(fieldName)
 
 				if field.IsValid() && field.CanSet() {
-					goVal := luaValToGo(value)
-
-					switch field.Kind() {
-					case reflect.String:
-						if v, ok := goVal.(string); ok {
-							field.SetString(v)
-						}
-					case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-						if v, ok := goVal.(int64); ok {
-							field.SetInt(v)
-						}
-					case reflect.Float32, reflect.Float64:
-						if v, ok := goVal.(float64); ok {
-							field.SetFloat(v)
-						}
-					case reflect.Bool:
-						if v, ok := goVal.(bool); ok {
-							field.SetBool(v)
-						}
-					default:
-						L.RaiseError("Unsupported field type: %s", field.Kind())
+					goVal, err := luaValToGoType(value, field.Type())
+					if err != nil {
+						L.RaiseError("%s: %s", fieldName, err)
 					}
+					field.Set(goVal)
 				}
 			}
 		})
@@ -162,13 +130,12 @@ func addType(L *lua.LState, namespace *lua.LTable, typ reflect.Type) {
 
 		// If an initialization table is provided, use "set" to populate fields
 		if nargs == 2 {
-			L.Push(instance) // Push the instance (self)
-			L.Push(L.Get(2)) // Push the table (argument 2)
+			// Call set with the instance (self) and the table (argument 2)
 			if err := L.CallByParam(lua.P{
 				Fn:      L.GetField(class, "set"),
 				NRet:    0,
 				Protect: true,
-			}); err != nil {
+			}, instance, L.Get(2)); err != nil {
 				L.RaiseError("%s", err)
 				return 0
 			}
@@ -181,28 +148,59 @@ func addType(L *lua.LState, namespace *lua.LTable, typ reflect.Type) {
 
 }
 
-func addTypes(L *lua.LState, namespace *lua.LTable, pkg string) {
+// callArgs converts the arguments on the stack to the parameter types of fn,
+// raising an error naming the position and expected type of an argument that
+// cannot be converted. Trailing arguments of variadic functions are converted
+// to the element type of the last parameter.
+func callArgs(L *lua.LState, fn reflect.Type) []reflect.Value {
+	nargs := L.GetTop()
+	if fn.IsVariadic() {
+		if nargs < fn.NumIn()-1 {
+			L.RaiseError("expected at least %d arguments, got %d", fn.NumIn()-1, nargs)
+			return nil
+		}
+	} else if nargs != fn.NumIn() {
+		L.RaiseError("expected %d arguments, got %d", fn.NumIn(), nargs)
+		return nil
+	}
 
-	cfg := packages.Config{Mode: packages.NeedTypes}
-	pkgs, _ := packages.Load(&cfg, pkg)
+	args := make([]reflect.Value, nargs)
+	for i := range args {
+		typ := paramType(fn, i)
+		arg, err := luaValToGoType(L.Get(i+1), typ)
+		if err != nil {
+			L.ArgError(i+1, fmt.Sprintf("expected %s: %s", typ, err))
+			return nil
+		}
+		args[i] = arg
+	}
+	return args
+}
 
-	for _, pkg := range pkgs {
-		scope := pkg.Types.Scope()
-		for _, name := range scope.Names() {
-			obj := scope.Lookup(name)
+// paramType returns the type of the i-th argument of fn.
+func paramType(fn reflect.Type, i int) reflect.Type {
+	if fn.IsVariadic() && i >= fn.NumIn()-1 {
+		return fn.In(fn.NumIn() - 1).Elem()
+	}
+	return fn.In(i)
+}
 
-			if fn, ok := obj.(*types.Func); ok {
-				if reflectType, isKnown := GetRegistry().Lookup(fn.FullName()); isKnown {
-					addFunction(L, namespace, fn.Name(), reflect.New(reflectType))
-				}
-			} else if reflectType, isKnown := GetRegistry().Lookup(obj.Type().String()); isKnown {
-				addType(L, namespace, reflectType)
-			}
+// addTypes adds the registered structs and functions of a package to a namespace.
+func addTypes(L *lua.LState, namespace *lua.LTable, pkg string) {
+	for name, val := range GetRegistry().Package(pkg) {
+		if val.Kind() == reflect.Func {
+			addFunction(L, namespace, name, val)
+		} else {
+			// by the name in the package, which differs from the type name for aliases such as client.ObjectKey
+			addType(L, namespace, name, val.Type())
 		}
 	}
 }
 
-func addObject(L *lua.LState, objName string, obj reflect.Value) error {
+// addObject exposes the methods of obj as global objName, together with the registered
+// types and functions of pkg. pkg is given explicitly rather than derived from obj, whose
+// concrete type may be of another package, e.g. a fake or a recorder of a client.
+func addObject(L *lua.LState, objName string, obj reflect.Value, pkg string) error {
 	typ := obj.Type()
 	if typ.Kind() != reflect.Ptr {
 		return errors.New("Object must be pointer: " + objName)
@@ -217,22 +215,7 @@ func addObject(L *lua.LState, objName string, obj reflect.Value) error {
 
 		if method.IsValid() {
 			L.SetField(namespace, methodName, L.NewFunction(func(L *lua.LState) int {
-				margs := method.Type().NumIn()
-				largs := L.GetTop()
-				if margs != largs {
-					L.RaiseError("Method %s expected %d arguments\n", methodName, largs)
-					return 0
-				}
-
-				args := make([]reflect.Value, largs)
-				for i := 1; i <= largs; i++ {
-					luaVal := L.Get(i)
-					goVal := luaValToGo(luaVal)
-					arg := reflect.ValueOf(goVal)
-					args[i-1] = arg
-				}
-
-				results := method.Call(args)
+				results := method.Call(callArgs(L, method.Type()))
 				nresults := len(results)
 				for i := 0; i < nresults; i++ {
 					L.Push(goValToLua(L, results[i]))
@@ -241,11 +224,17 @@ func addObject(L *lua.LState, objName string, obj reflect.Value) error {
 			}))
 		}
 	}
-	addTypes(L, namespace, obj.Elem().Type().PkgPath())
+	addTypes(L, namespace, pkg)
 
 	return nil
 }
 
+// apiNamespace is a global exposing the types of an API group, e.g. apps.Deployment.
+type apiNamespace struct {
+	name string
+	pkg  string
+}
+
 func addNamespace(L *lua.LState, name string) *lua.LTable {
 	ns := L.NewTable()
 	ns.RawSetString(LUA_TABLE_NAME, lua.LString(name))
@@ -0,0 +1,129 @@
+package lua
+
+import (
+	"context"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	lua "github.com/yuin/gopher-lua"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Bindings", func() {
+	var L *lua.LState
+
+	BeforeEach(func() {
+		L = lua.NewState()
+		DeferCleanup(L.Close)
+		addTypes(L, addNamespace(L, "core"), "k8s.io/api/core/v1")
+	})
+
+	// bind exposes fn as global f
+	bind := func(fn any) {
+		L.SetGlobal("f", newFunction(L, reflect.ValueOf(fn)))
+	}
+
+	Context("when converting arguments", func() {
+		It("should convert tables to structs and pointers", func() {
+			var key client.ObjectKey
+			var pod *corev1.Pod
+			bind(func(k client.ObjectKey, p *corev1.Pod) { key, pod = k, p })
+
+			Expect(L.DoString(`f({Namespace = "default", Name = "web"}, {Spec = {NodeName = "node-1"}})`)).To(Succeed())
+			Expect(key).To(Equal(client.ObjectKey{Namespace: "default", Name: "web"}))
+			Expect(pod.Spec.NodeName).To(Equal("node-1"))
+		})
+
+		It("should pass bound values as they are", func() {
+			var pod *corev1.Pod
+			bind(func(p *corev1.Pod) { p.Name = "web"; pod = p })
+
+			Expect(L.DoString(`p = core.Pod:new({}); f(p)`)).To(Succeed())
+			Expect(pod.Name).To(Equal("web"))
+		})
+
+		It("should narrow numbers to int kinds", func() {
+			var args []any
+			bind(func(a int8, b int32, c uint16, d int64, e int) { args = []any{a, b, c, d, e} })
+
+			Expect(L.DoString(`f(-8, 32, 16, 2^53, 7)`)).To(Succeed())
+			Expect(args).To(Equal([]any{int8(-8), int32(32), uint16(16), int64(1 << 53), 7}))
+		})
+
+		It("should convert nil to zero values", func() {
+			var args []any
+			bind(func(a *corev1.Pod, b []string, c map[string]string, d string, e int32) {
+				args = []any{a, b, c, d, e}
+			})
+
+			Expect(L.DoString(`f(nil, nil, nil, nil, nil)`)).To(Succeed())
+			Expect(args).To(Equal([]any{(*corev1.Pod)(nil), []string(nil), map[string]string(nil), "", int32(0)}))
+		})
+
+		It("should raise an error naming the argument and expected type", func() {
+			bind(func(name string, replicas int32) {})
+
+			err := L.DoString(`f("web", 1.5)`)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("bad argument #2"))
+			Expect(err.Error()).To(ContainSubstring("(expected int32: 1.5 is not an int32)"))
+
+			err = L.DoString(`f({}, 1)`)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("bad argument #1"))
+			Expect(err.Error()).To(ContainSubstring("(expected string:"))
+		})
+	})
+
+	Context("when calling variadic functions", func() {
+		BeforeEach(func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			DeferCleanup(cancel)
+			c := fake.NewClientBuilder().WithObjects(
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}},
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "kube-system"}},
+			).Build()
+			Expect(addObject(L, "ctx", reflect.ValueOf(ctx), "context")).To(Succeed())
+			Expect(addObject(L, "client", reflect.ValueOf(c), "sigs.k8s.io/controller-runtime/pkg/client")).To(Succeed())
+		})
+
+		// list lists pods with the given trailing arguments of client.List
+		list := func(opts string) (*corev1.PodList, error) {
+			if err := L.DoString(`pods = core.PodList:new({}); assert(client.List(ctx, pods` + opts + `) == nil)`); err != nil {
+				return nil, err
+			}
+			return boundValue(L.GetGlobal("pods")).Interface().(*corev1.PodList), nil
+		}
+
+		DescribeTable("should convert trailing arguments to the element type",
+			func(opts string, count int) {
+				pods, err := list(opts)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pods.Items).To(HaveLen(count))
+			},
+			Entry("without options", ``, 3),
+			Entry("with one option", `, client.ListOptions:new({Namespace = "default"})`, 2),
+			Entry("with several options",
+				`, client.ListOptions:new({Namespace = "default"}), client.ListOptions:new({Namespace = "kube-system"}), client.ListOptions:new({})`, 1),
+		)
+
+		It("should raise an error for trailing arguments that cannot be converted", func() {
+			_, err := list(`, "default"`)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("bad argument #3"))
+			Expect(err.Error()).To(ContainSubstring("(expected client.ListOption:"))
+		})
+
+		It("should raise an error for missing arguments", func() {
+			err := L.DoString(`client.List(ctx)`)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("expected at least 2 arguments, got 1"))
+		})
+	})
+})
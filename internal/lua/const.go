@@ -5,4 +5,5 @@ const (
 	LUA_TABLE_NAME   = "__NAME__"
 	LUA_TABLE_PTR    = "__PTR__"
 	LUA_TABLE_STRUCT = "__STRUCT__"
+	LUA_JSON_ARRAY   = "__ARRAY__"
 )
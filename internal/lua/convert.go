@@ -1,6 +1,7 @@
 package lua
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
@@ -8,20 +9,24 @@ import (
 	lua "github.com/yuin/gopher-lua"
 )
 
+// luaValToGo converts a Lua value to a Go value without a target type.
+// Integral numbers become int, tables with positive integer keys []any,
+// with nil for missing elements, and other tables map[string]any. An empty
+// table is an empty slice, nil is nil. Use luaValToGoType to convert to a
+// specific type.
 func luaValToGo(val lua.LValue) any {
 	switch v := val.(type) {
 	case *lua.LNilType:
 		return nil
 	case *lua.LTable:
 		// either array
-		if isArrayTable(v) {
-			var result []any
-			v.ForEach(func(_, value lua.LValue) {
-				result = append(result, luaValToGo(value))
-			})
+		if n, ok := arrayLen(v); ok {
+			result := make([]any, n)
+			for i := 1; i <= n; i++ {
+				result[i-1] = luaValToGo(v.RawGetInt(i))
+			}
 			return result
 		}
-		// or map
 		result := make(map[string]any)
 		// if it is a map it may be a ptr
 		maybePtr := v.RawGetString(LUA_TABLE_PTR)
@@ -58,6 +63,27 @@ func luaValToGo(val lua.LValue) any {
 	}
 }
 
+// arrayLen returns the length of tbl as an array if all keys are positive integers.
+// Arrays may be sparse, e.g. {1, nil, 3}, but at most half of their elements missing,
+// such that {[1000000] = 1} is not an array.
+func arrayLen(tbl *lua.LTable) (int, bool) {
+	n, maxKey := 0, 0
+	isArray := true
+	tbl.ForEach(func(key, _ lua.LValue) {
+		n++
+		num, ok := key.(lua.LNumber)
+		if !ok || float64(num) != math.Trunc(float64(num)) || num < 1 {
+			isArray = false
+			return
+		}
+		maxKey = max(maxKey, int(num))
+	})
+	if !isArray || maxKey > 2*n {
+		return 0, false
+	}
+	return maxKey, true
+}
+
 func goValToLua(L *lua.LState, val reflect.Value) lua.LValue {
 
 	// Handle invalid or nil values (e.g., uninitialized reflect.Value)
@@ -65,8 +91,17 @@ func goValToLua(L *lua.LState, val reflect.Value) lua.LValue {
 		return lua.LNil
 	}
 
+	if isWellKnown(val.Type()) {
+		return wellKnownToLua(val)
+	}
+
 	switch val.Kind() {
 	case reflect.Map:
+		// nil maps and slices become nil, empty ones empty tables
+		if val.IsNil() {
+			return lua.LNil
+		}
+		allocate(L, tableSize+int64(val.Len())*entrySize)
 		result := L.NewTable()
 		for _, key := range val.MapKeys() {
 			luaKey := goValToLua(L, key)
@@ -76,6 +111,17 @@ func goValToLua(L *lua.LState, val reflect.Value) lua.LValue {
 		return result
 
 	case reflect.Slice, reflect.Array:
+		if val.Kind() == reflect.Slice && val.IsNil() {
+			return lua.LNil
+		}
+		// e.g. Secret.Data
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			allocate(L, valueSize+int64(val.Len()))
+			b := make([]byte, val.Len())
+			reflect.Copy(reflect.ValueOf(b), val)
+			return lua.LString(b)
+		}
+		allocate(L, tableSize+int64(val.Len())*entrySize)
 		result := L.NewTable()
 		for i := 0; i < val.Len(); i++ {
 			result.RawSetInt(i+1, goValToLua(L, val.Index(i)))
@@ -83,6 +129,15 @@ func goValToLua(L *lua.LState, val reflect.Value) lua.LValue {
 		return result
 
 	case reflect.Ptr:
+		// pointers to scalars, e.g. optional fields like Spec.Replicas, are dereferenced
+		if elem := val.Type().Elem(); isWellKnown(elem) || isScalar(elem.Kind()) {
+			if val.IsNil() {
+				return lua.LNil
+			}
+			return goValToLua(L, val.Elem())
+		}
+
+		allocate(L, tableSize+2*(entrySize+userDataSize))
 		result := L.NewTable()
 		__ptr__ := L.NewUserData()
 
@@ -103,8 +158,9 @@ func goValToLua(L *lua.LState, val reflect.Value) lua.LValue {
 		return result
 
 	case reflect.Struct:
-		result := L.NewTable()
 		structType := val.Type()
+		allocate(L, tableSize+int64(structType.NumField()+2)*entrySize+2*userDataSize)
+		result := L.NewTable()
 
 		__struct__ := L.NewUserData()
 		__struct__.Value = val.Interface()
@@ -123,20 +179,398 @@ func goValToLua(L *lua.LState, val reflect.Value) lua.LValue {
 		return result
 
 	case reflect.String:
+		allocate(L, valueSize+int64(val.Len()))
 		return lua.LString(val.String())
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return lua.LNumber(val.Int())
 
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return lua.LNumber(val.Uint())
+
 	case reflect.Float32, reflect.Float64:
 		return lua.LNumber(val.Float())
 
 	case reflect.Bool:
 		return lua.LBool(val.Bool())
 
+	case reflect.Interface:
+		// e.g. errors returned by functions, converted by their dynamic type
+		return goValToLua(L, val.Elem())
+
+	case reflect.Func:
+		if val.IsNil() {
+			return lua.LNil
+		}
+		allocate(L, functionSize)
+		return newFunction(L, val)
+
+	case reflect.Chan, reflect.UnsafePointer:
+		// opaque to scripts, but can be passed back to Go
+		ud := L.NewUserData()
+		ud.Value = val.Interface()
+		return ud
+
 	default:
 		printStackTrace()
 		L.RaiseError("Unsupported Go value type: %s", val.Kind())
 		return lua.LNil
 	}
 }
+
+// luaValToGoType converts a Lua value to a Go value of type typ. Numbers are
+// converted to any numeric type they fit into, strings to []byte and to string
+// types such as types.UID, tables to slices, arrays, maps and structs by field
+// name, and strings or numbers to well-known types like metav1.Time. Go values
+// bound to the script are used as is if they are assignable to typ. nil becomes
+// the zero value of typ, whereas an empty table is an empty slice or map.
+func luaValToGoType(val lua.LValue, typ reflect.Type) (reflect.Value, error) {
+	if val == lua.LNil || isNilPointer(val) {
+		return reflect.Zero(typ), nil
+	}
+
+	// Go values bound to the script
+	if bound := boundValue(val); bound.IsValid() {
+		switch {
+		case bound.Type().AssignableTo(typ):
+			return bound, nil
+		case typ.Kind() == reflect.Ptr && bound.Type().AssignableTo(typ.Elem()):
+			ptr := reflect.New(typ.Elem())
+			ptr.Elem().Set(bound)
+			return ptr, nil
+		case bound.Kind() == reflect.Ptr && !bound.IsNil() && bound.Elem().Type().AssignableTo(typ):
+			return bound.Elem(), nil
+		}
+		return reflect.Value{}, fmt.Errorf("cannot use %s as %s", bound.Type(), typ)
+	}
+
+	if isWellKnown(typ) {
+		return luaToWellKnown(val, typ)
+	}
+
+	result := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.Interface:
+		v := luaValToGo(val)
+		if v == nil {
+			return result, nil
+		}
+		if rv := reflect.ValueOf(v); rv.Type().AssignableTo(typ) {
+			result.Set(rv)
+			return result, nil
+		}
+
+	case reflect.Ptr:
+		elem, err := luaValToGoType(val, typ.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(typ.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+
+	case reflect.Bool:
+		if b, ok := val.(lua.LBool); ok {
+			result.SetBool(bool(b))
+			return result, nil
+		}
+
+	case reflect.String:
+		if s, ok := val.(lua.LString); ok {
+			result.SetString(string(s))
+			return result, nil
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := val.(lua.LNumber); ok {
+			i, err := toInt(n, typ.Bits())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			result.SetInt(i)
+			return result, nil
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n, ok := val.(lua.LNumber); ok {
+			f := float64(n)
+			if f < 0 || f != math.Trunc(f) || f >= math.Ldexp(1, typ.Bits()) {
+				return reflect.Value{}, fmt.Errorf("%v overflows %s", f, typ)
+			}
+			result.SetUint(uint64(f))
+			return result, nil
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if n, ok := val.(lua.LNumber); ok {
+			result.SetFloat(float64(n))
+			return result, nil
+		}
+
+	case reflect.Slice:
+		if s, ok := val.(lua.LString); ok && typ.Elem().Kind() == reflect.Uint8 {
+			result.SetBytes([]byte(s))
+			return result, nil
+		}
+		if tbl, ok := val.(*lua.LTable); ok {
+			n, isArray := arrayLen(tbl)
+			if !isArray {
+				return reflect.Value{}, fmt.Errorf("expected array, got table")
+			}
+			result = reflect.MakeSlice(typ, n, n)
+			return result, setElements(result, tbl, n)
+		}
+
+	case reflect.Array:
+		if tbl, ok := val.(*lua.LTable); ok {
+			n, isArray := arrayLen(tbl)
+			if !isArray || n > typ.Len() {
+				return reflect.Value{}, fmt.Errorf("expected array of at most %d elements", typ.Len())
+			}
+			return result, setElements(result, tbl, n)
+		}
+
+	case reflect.Map:
+		if tbl, ok := val.(*lua.LTable); ok {
+			result = reflect.MakeMap(typ)
+			var err error
+			tbl.ForEach(func(key, value lua.LValue) {
+				if err != nil {
+					return
+				}
+				var k, v reflect.Value
+				if k, err = luaValToGoType(key, typ.Key()); err != nil {
+					err = fmt.Errorf("key %s: %w", key, err)
+					return
+				}
+				if v, err = luaValToGoType(value, typ.Elem()); err != nil {
+					err = fmt.Errorf("%s: %w", key, err)
+					return
+				}
+				result.SetMapIndex(k, v)
+			})
+			return result, err
+		}
+
+	case reflect.Struct:
+		if tbl, ok := val.(*lua.LTable); ok {
+			var err error
+			tbl.ForEach(func(key, value lua.LValue) {
+				if err != nil {
+					return
+				}
+				name, ok := key.(lua.LString)
+				if !ok {
+					return
+				}
+				switch name {
+				case LUA_TABLE_TYPE, LUA_TABLE_NAME, LUA_TABLE_PTR, LUA_TABLE_STRUCT:
+					return
+				}
+				field := result.FieldByName(string(name))
+				if !field.IsValid() || !field.CanSet() {
+					err = fmt.Errorf("%s has no field %s", typ, name)
+					return
+				}
+				var v reflect.Value
+				if v, err = luaValToGoType(value, field.Type()); err != nil {
+					err = fmt.Errorf("%s: %w", name, err)
+					return
+				}
+				field.Set(v)
+			})
+			return result, err
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("cannot convert %s to %s", val.Type(), typ)
+}
+
+// boundValue returns the Go value of a table or user data created by goValToLua,
+// or the zero Value if val is not bound to a Go value.
+func boundValue(val lua.LValue) reflect.Value {
+	switch v := val.(type) {
+	case *lua.LUserData:
+		return reflect.ValueOf(v.Value)
+	case *lua.LTable:
+		for _, field := range []string{LUA_TABLE_PTR, LUA_TABLE_STRUCT} {
+			if ud, ok := v.RawGetString(field).(*lua.LUserData); ok {
+				return reflect.ValueOf(ud.Value)
+			}
+		}
+	}
+	return reflect.Value{}
+}
+
+// isNilPointer returns true if val is the table of a nil pointer created by goValToLua.
+// The type of such pointers is unknown, they convert to the zero value of any type.
+func isNilPointer(val lua.LValue) bool {
+	tbl, ok := val.(*lua.LTable)
+	if !ok {
+		return false
+	}
+	ud, ok := tbl.RawGetString(LUA_TABLE_PTR).(*lua.LUserData)
+	return ok && ud.Value == nil
+}
+
+// setElements sets the first n elements of the slice or array result to the
+// elements of tbl. Missing elements of sparse arrays are left at their zero value.
+func setElements(result reflect.Value, tbl *lua.LTable, n int) error {
+	for i := 1; i <= n; i++ {
+		elem, err := luaValToGoType(tbl.RawGetInt(i), result.Type().Elem())
+		if err != nil {
+			return fmt.Errorf("[%d]: %w", i, err)
+		}
+		result.Index(i - 1).Set(elem)
+	}
+	return nil
+}
+
+// toInt converts an integral number to an int64 that fits into bits.
+func toInt(n lua.LNumber, bits int) (int64, error) {
+	f := float64(n)
+	limit := math.Ldexp(1, bits-1)
+	if f != math.Trunc(f) || f < -limit || f >= limit {
+		return 0, fmt.Errorf("%v is not an int%d", f, bits)
+	}
+	return int64(f), nil
+}
+
+func isScalar(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// jsonValToLua converts JSON-like values, i.e. maps, slices and scalars as
+// produced by encoding/json or found in unstructured objects, to plain Lua
+// tables and values. Values of any other type are converted by goValToLua.
+func jsonValToLua(L *lua.LState, val any) lua.LValue {
+	switch v := val.(type) {
+	case nil:
+		return lua.LNil
+	case map[string]any:
+		allocate(L, tableSize+int64(len(v))*entrySize)
+		result := L.NewTable()
+		for key, value := range v {
+			result.RawSetString(key, jsonValToLua(L, value))
+		}
+		return result
+	case []any:
+		allocate(L, tableSize+int64(len(v))*entrySize)
+		result := L.NewTable()
+		for i, value := range v {
+			result.RawSetInt(i+1, jsonValToLua(L, value))
+		}
+		// keeps empty arrays arrays when converted back by luaValToJSON
+		mt := L.NewTypeMetatable(LUA_JSON_ARRAY)
+		mt.RawSetString("__name", lua.LString(LUA_JSON_ARRAY))
+		result.Metatable = mt
+		return result
+	case string:
+		allocate(L, valueSize+int64(len(v)))
+		return lua.LString(v)
+	case bool:
+		return lua.LBool(v)
+	case int64:
+		return lua.LNumber(v)
+	case float64:
+		return lua.LNumber(v)
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return lua.LString(v)
+		}
+		return lua.LNumber(f)
+	default:
+		return goValToLua(L, reflect.ValueOf(v))
+	}
+}
+
+// readOnlyTable returns a proxy of tbl that raises an error when the script
+// assigns to it. Nested tables are not protected.
+func readOnlyTable(L *lua.LState, name string, tbl *lua.LTable) *lua.LTable {
+	meta := L.NewTable()
+	meta.RawSetString("__index", tbl)
+	meta.RawSetString("__newindex", L.NewFunction(func(L *lua.LState) int {
+		L.RaiseError("%s is read-only", name)
+		return 0
+	}))
+	// prevent the metatable from being replaced
+	meta.RawSetString("__metatable", lua.LFalse)
+
+	proxy := L.NewTable()
+	L.SetMetatable(proxy, meta)
+	return proxy
+}
+
+// luaValToJSON converts a Lua value to a value that encoding/json can marshal.
+// Tables with consecutive integer keys starting at 1 become arrays, as do empty
+// tables converted from arrays by jsonValToLua, other tables objects. Go values
+// bound to the script are returned as is.
+func luaValToJSON(val lua.LValue) (any, error) {
+	switch v := val.(type) {
+	case *lua.LNilType:
+		return nil, nil
+	case lua.LBool:
+		return bool(v), nil
+	case lua.LNumber:
+		return float64(v), nil
+	case lua.LString:
+		return string(v), nil
+	case *lua.LUserData:
+		return v.Value, nil
+	case *lua.LTable:
+		if ptr := v.RawGetString(LUA_TABLE_PTR); ptr != lua.LNil {
+			return ptr.(*lua.LUserData).Value, nil
+		}
+		if strct := v.RawGetString(LUA_TABLE_STRUCT); strct != lua.LNil {
+			return strct.(*lua.LUserData).Value, nil
+		}
+
+		if n := v.MaxN(); (n > 0 || isJSONArray(v)) && n == countKeys(v) {
+			result := make([]any, n)
+			for i := 1; i <= n; i++ {
+				item, err := luaValToJSON(v.RawGetInt(i))
+				if err != nil {
+					return nil, fmt.Errorf("[%d]: %w", i, err)
+				}
+				result[i-1] = item
+			}
+			return result, nil
+		}
+
+		result := make(map[string]any)
+		var err error
+		v.ForEach(func(key, value lua.LValue) {
+			if err != nil {
+				return
+			}
+			k := key.String()
+			if result[k], err = luaValToJSON(value); err != nil {
+				err = fmt.Errorf("%s: %w", k, err)
+			}
+		})
+		return result, err
+	default:
+		return nil, fmt.Errorf("cannot convert %s to JSON", val.Type())
+	}
+}
+
+// isJSONArray reports whether tbl was converted from an array by jsonValToLua.
+func isJSONArray(tbl *lua.LTable) bool {
+	mt, ok := tbl.Metatable.(*lua.LTable)
+	return ok && mt.RawGetString("__name") == lua.LString(LUA_JSON_ARRAY)
+}
+
+func countKeys(tbl *lua.LTable) int {
+	n := 0
+	tbl.ForEach(func(_, _ lua.LValue) {
+		n++
+	})
+	return n
+}
@@ -0,0 +1,176 @@
+package lua
+
+import (
+	"reflect"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	lua "github.com/yuin/gopher-lua"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Value conversion", func() {
+	var L *lua.LState
+
+	BeforeEach(func() {
+		L = lua.NewState()
+		DeferCleanup(L.Close)
+	})
+
+	// eval returns the value of a Lua expression
+	eval := func(expr string) lua.LValue {
+		Expect(L.DoString("return " + expr)).To(Succeed())
+		defer L.Pop(1)
+		return L.Get(-1)
+	}
+
+	// convert converts a Lua expression to the type of example
+	convert := func(expr string, example any) (any, error) {
+		val, err := luaValToGoType(eval(expr), reflect.TypeOf(example))
+		if err != nil {
+			return nil, err
+		}
+		return val.Interface(), nil
+	}
+
+	timestamp := metav1.NewTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	DescribeTable("should round-trip Go values through Lua",
+		func(val any) {
+			result, err := luaValToGoType(goValToLua(L, reflect.ValueOf(val)), reflect.TypeOf(val))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Interface()).To(Equal(val))
+		},
+		Entry("uint", uint(7)),
+		Entry("uint8", uint8(255)),
+		Entry("uint16", uint16(65535)),
+		Entry("uint32", uint32(4294967295)),
+		Entry("uint64", uint64(1<<53)),
+		Entry("int32", int32(-2147483648)),
+		Entry("int64", int64(-1<<53)),
+		Entry("[]byte", []byte("a\x00b")),
+		Entry("types.UID", types.UID("8f6a2c1e")),
+		Entry("metav1.Time", timestamp),
+		Entry("*metav1.Time", &timestamp),
+		Entry("intstr.IntOrString of int", intstr.FromInt32(8080)),
+		Entry("intstr.IntOrString of string", intstr.FromString("http")),
+		Entry("*int32", ptr.To[int32](3)),
+		Entry("nil *int32", (*int32)(nil)),
+		Entry("nil slice", []string(nil)),
+		Entry("empty slice", []string{}),
+		Entry("nil map", map[string]string(nil)),
+		Entry("empty map", map[string]string{}),
+	)
+
+	DescribeTable("should convert Go values to Lua values",
+		func(val any, expected lua.LValue) {
+			Expect(goValToLua(L, reflect.ValueOf(val))).To(Equal(expected))
+		},
+		Entry("uint64", uint64(42), lua.LNumber(42)),
+		Entry("[]byte", []byte("secret"), lua.LString("secret")),
+		Entry("types.UID", types.UID("8f6a2c1e"), lua.LString("8f6a2c1e")),
+		Entry("metav1.Time", timestamp, lua.LString("2025-01-02T03:04:05Z")),
+		Entry("zero metav1.Time", metav1.Time{}, lua.LNil),
+		Entry("resource.Quantity", resource.MustParse("500m"), lua.LString("500m")),
+		Entry("intstr.IntOrString of int", intstr.FromInt32(1), lua.LNumber(1)),
+		Entry("intstr.IntOrString of string", intstr.FromString("25%"), lua.LString("25%")),
+		Entry("*int32", ptr.To[int32](3), lua.LNumber(3)),
+		Entry("nil *string", (*string)(nil), lua.LNil),
+		Entry("nil slice", []string(nil), lua.LNil),
+		Entry("nil map", map[string]string(nil), lua.LNil),
+	)
+
+	It("should convert interfaces by their dynamic type", func() {
+		var val any = uint8(3)
+		Expect(goValToLua(L, reflect.ValueOf(&val).Elem())).To(Equal(lua.LNumber(3)))
+
+		result, err := convert(`{a = 1, b = "x"}`, map[string]any{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(map[string]any{"a": 1, "b": "x"}))
+	})
+
+	It("should convert empty collections to empty tables", func() {
+		tbl, ok := goValToLua(L, reflect.ValueOf([]string{})).(*lua.LTable)
+		Expect(ok).To(BeTrue())
+		Expect(tbl.Len()).To(BeZero())
+	})
+
+	DescribeTable("should convert Lua values to Go types",
+		func(expr string, expected any) {
+			Expect(convert(expr, expected)).To(Equal(expected))
+		},
+		Entry("number to int32", `42`, int32(42)),
+		Entry("number to int64", `-42`, int64(-42)),
+		Entry("number to uint16", `42`, uint16(42)),
+		Entry("string to []byte", `"a\0b"`, []byte("a\x00b")),
+		Entry("string to types.UID", `"8f6a2c1e"`, types.UID("8f6a2c1e")),
+		Entry("string to metav1.Time", `"2025-01-02T03:04:05Z"`, timestamp),
+		Entry("number to resource.Quantity", `1.5`, resource.MustParse("1.5")),
+		Entry("number to intstr.IntOrString", `8080`, intstr.FromInt32(8080)),
+		Entry("string to intstr.IntOrString", `"25%"`, intstr.FromString("25%")),
+		Entry("nil to nil slice", `nil`, []string(nil)),
+		Entry("empty table to empty slice", `{}`, []string{}),
+		Entry("empty table to empty map", `{}`, map[string]string{}),
+		Entry("sparse array to slice", `{1, nil, 3}`, []int32{1, 0, 3}),
+		Entry("table to struct", `{Name = "http", ContainerPort = 80, Protocol = "TCP"}`,
+			corev1.ContainerPort{Name: "http", ContainerPort: 80, Protocol: corev1.ProtocolTCP}),
+		Entry("table to pointer", `{Name = "http"}`, &corev1.ContainerPort{Name: "http"}),
+	)
+
+	DescribeTable("should reject Lua values that do not fit",
+		func(expr string, example any) {
+			_, err := convert(expr, example)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("overflowing uint8", `256`, uint8(0)),
+		Entry("negative uint32", `-1`, uint32(0)),
+		Entry("fraction to int64", `1.5`, int64(0)),
+		Entry("overflowing int32", `2^31`, int32(0)),
+		Entry("string to int32", `"1"`, int32(0)),
+		Entry("invalid timestamp", `"yesterday"`, metav1.Time{}),
+		Entry("invalid quantity", `"lots"`, resource.Quantity{}),
+		Entry("map to slice", `{a = 1}`, []string{}),
+		Entry("unknown field", `{Port = 80}`, corev1.ContainerPort{}),
+	)
+
+	DescribeTable("should convert Lua values without target type",
+		func(expr string, expected any) {
+			Expect(luaValToGo(eval(expr))).To(Equal(expected))
+		},
+		Entry("integral number", `42`, 42),
+		Entry("fractional number", `1.5`, 1.5),
+		Entry("empty table", `{}`, []any{}),
+		Entry("sparse array", `{1, nil, 3}`, []any{1, nil, 3}),
+		Entry("too sparse array", `{[1000] = 1}`, map[string]any{"1000": 1}),
+		Entry("table", `{a = true}`, map[string]any{"a": true}),
+	)
+
+	It("should convert tables of nil pointers to zero values", func() {
+		tbl := goValToLua(L, reflect.ValueOf((*corev1.Pod)(nil)))
+		val, err := luaValToGoType(tbl, reflect.TypeOf(&corev1.Pod{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(val.IsNil()).To(BeTrue())
+
+		L.SetGlobal("pod", tbl)
+		val, err = luaValToGoType(eval(`{Name = "web", Spec = pod}`), reflect.TypeOf(struct {
+			Name string
+			Spec *corev1.PodSpec
+		}{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(val.Field(1).IsNil()).To(BeTrue())
+	})
+
+	It("should use Go values bound to the script", func() {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web"}}
+		val, err := luaValToGoType(goValToLua(L, reflect.ValueOf(pod)), reflect.TypeOf(&corev1.Pod{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(val.Interface()).To(BeIdenticalTo(pod))
+	})
+})
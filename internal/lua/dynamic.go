@@ -0,0 +1,220 @@
+package lua
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	lua "github.com/yuin/gopher-lua"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// patchTypes are the patch types accepted by dynamic.patch by name.
+var patchTypes = map[string]types.PatchType{
+	"merge":     types.MergePatchType,
+	"json":      types.JSONPatchType,
+	"strategic": types.StrategicMergePatchType,
+}
+
+// WithDynamicClient exposes d to the script as dynamic, resolving kinds to resources
+// through mapper. Without it, a dynamic client is created from the kubeconfig of the process.
+func WithDynamicClient(d dynamic.Interface, mapper meta.RESTMapper) Option {
+	return func(o *execOptions) {
+		o.dynamic = d
+		o.mapper = mapper
+	}
+}
+
+// dynamicBinding implements the dynamic global, which reads and writes objects of
+// any kind as plain tables in their JSON representation.
+type dynamicBinding struct {
+	ctx    context.Context
+	client dynamic.Interface
+	mapper meta.RESTMapper
+}
+
+// addDynamic defines the dynamic global.
+func addDynamic(L *lua.LState, ctx context.Context, d dynamic.Interface, mapper meta.RESTMapper) {
+	b := &dynamicBinding{ctx: ctx, client: d, mapper: mapper}
+	ns := L.NewTable()
+	L.SetFuncs(ns, map[string]lua.LGFunction{
+		"get":    b.get,
+		"list":   b.list,
+		"create": b.create,
+		"update": b.update,
+		"patch":  b.patch,
+		"delete": b.delete,
+	})
+	L.SetGlobal("dynamic", ns)
+}
+
+// resource returns the client of the resource of a kind in namespace,
+// which is ignored for cluster-scoped kinds.
+func (b *dynamicBinding) resource(apiVersion, kind, namespace string) (dynamic.ResourceInterface, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := b.mapper.RESTMapping(gv.WithKind(kind).GroupKind(), gv.Version)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return b.client.Resource(mapping.Resource).Namespace(namespace), nil
+	}
+	return b.client.Resource(mapping.Resource), nil
+}
+
+// get(apiVersion, kind, name [, namespace]) returns an object or nil and an error.
+func (b *dynamicBinding) get(L *lua.LState) int {
+	res, err := b.resource(L.CheckString(1), L.CheckString(2), L.OptString(4, ""))
+	if err != nil {
+		return pushError(L, err)
+	}
+	obj, err := res.Get(b.ctx, L.CheckString(3), metav1.GetOptions{})
+	if err != nil {
+		return pushError(L, err)
+	}
+	L.Push(jsonValToLua(L, obj.Object))
+	return 1
+}
+
+// list(apiVersion, kind [, namespace [, options]]) returns a list with the objects as items
+// or nil and an error. Namespaced kinds are listed in all namespaces if namespace is empty.
+// options are the fields of metav1.ListOptions, such as labelSelector and limit.
+func (b *dynamicBinding) list(L *lua.LState) int {
+	res, err := b.resource(L.CheckString(1), L.CheckString(2), L.OptString(3, ""))
+	if err != nil {
+		return pushError(L, err)
+	}
+	var opts metav1.ListOptions
+	if tbl := L.OptTable(4, nil); tbl != nil {
+		if err := fromTable(tbl, &opts); err != nil {
+			L.ArgError(4, err.Error())
+		}
+	}
+	list, err := res.List(b.ctx, opts)
+	if err != nil {
+		return pushError(L, err)
+	}
+	L.Push(jsonValToLua(L, list.UnstructuredContent()))
+	return 1
+}
+
+// create(object) creates an object and returns it as created or nil and an error.
+func (b *dynamicBinding) create(L *lua.LState) int {
+	obj := checkObject(L, 1)
+	res, err := b.resource(obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace())
+	if err != nil {
+		return pushError(L, err)
+	}
+	if obj, err = res.Create(b.ctx, obj, metav1.CreateOptions{}); err != nil {
+		return pushError(L, err)
+	}
+	L.Push(jsonValToLua(L, obj.Object))
+	return 1
+}
+
+// update(object) replaces an object and returns it as updated or nil and an error.
+func (b *dynamicBinding) update(L *lua.LState) int {
+	obj := checkObject(L, 1)
+	res, err := b.resource(obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace())
+	if err != nil {
+		return pushError(L, err)
+	}
+	if obj, err = res.Update(b.ctx, obj, metav1.UpdateOptions{}); err != nil {
+		return pushError(L, err)
+	}
+	L.Push(jsonValToLua(L, obj.Object))
+	return 1
+}
+
+// patch(apiVersion, kind, name, namespace, patch [, patchType]) patches an object and
+// returns it as patched or nil and an error. patch is a table or a JSON string, patchType
+// one of merge (default), json and strategic.
+func (b *dynamicBinding) patch(L *lua.LState) int {
+	patchType, ok := patchTypes[L.OptString(6, "merge")]
+	if !ok {
+		L.ArgError(6, "patch type must be one of merge, json and strategic")
+	}
+	var data []byte
+	switch patch := L.CheckAny(5).(type) {
+	case lua.LString:
+		data = []byte(patch)
+	case *lua.LTable:
+		value, err := luaValToJSON(patch)
+		if err == nil {
+			data, err = json.Marshal(value)
+		}
+		if err != nil {
+			L.ArgError(5, err.Error())
+		}
+	default:
+		L.ArgError(5, "patch must be a table or a string")
+	}
+
+	res, err := b.resource(L.CheckString(1), L.CheckString(2), L.OptString(4, ""))
+	if err != nil {
+		return pushError(L, err)
+	}
+	obj, err := res.Patch(b.ctx, L.CheckString(3), patchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return pushError(L, err)
+	}
+	L.Push(jsonValToLua(L, obj.Object))
+	return 1
+}
+
+// delete(apiVersion, kind, name [, namespace]) deletes an object and returns true or nil and an error.
+func (b *dynamicBinding) delete(L *lua.LState) int {
+	res, err := b.resource(L.CheckString(1), L.CheckString(2), L.OptString(4, ""))
+	if err != nil {
+		return pushError(L, err)
+	}
+	if err := res.Delete(b.ctx, L.CheckString(3), metav1.DeleteOptions{}); err != nil {
+		return pushError(L, err)
+	}
+	L.Push(lua.LTrue)
+	return 1
+}
+
+// checkObject returns the object passed as argument n.
+func checkObject(L *lua.LState, n int) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	if err := fromTable(L.CheckTable(n), &obj.Object); err != nil {
+		L.ArgError(n, err.Error())
+	}
+	if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+		L.ArgError(n, "object must have apiVersion and kind")
+	}
+	return obj
+}
+
+// fromTable decodes a table into v through its JSON representation.
+func fromTable(tbl *lua.LTable, v any) error {
+	value, err := luaValToJSON(tbl)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid table: %w", err)
+	}
+	return nil
+}
+
+// pushError returns nil and err to the script, so that the script can raise it with error.
+func pushError(L *lua.LState, err error) int {
+	L.Push(lua.LNil)
+	L.Push(goValToLua(L, reflect.ValueOf(err)))
+	return 2
+}
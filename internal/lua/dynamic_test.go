@@ -0,0 +1,139 @@
+package lua
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	lua "github.com/yuin/gopher-lua"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var _ = Describe("Dynamic client", func() {
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	namespaces := schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+
+	var (
+		L      *lua.LState
+		client *dynamicfake.FakeDynamicClient
+	)
+
+	newPod := func(name string, labels map[string]any) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata":   map[string]any{"name": name, "namespace": "default", "labels": labels},
+			"spec": map[string]any{
+				"containers": []any{map[string]any{"name": "main", "image": "busybox", "args": []any{}}},
+			},
+		}}
+	}
+
+	BeforeEach(func() {
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+		mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, meta.RESTScopeRoot)
+
+		client = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{pods: "PodList", namespaces: "NamespaceList"},
+			newPod("web", map[string]any{"app": "web"}),
+			newPod("db", map[string]any{"app": "db"}),
+			&unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "v1", "kind": "Namespace", "metadata": map[string]any{"name": "default"},
+			}},
+		)
+
+		L = lua.NewState()
+		DeferCleanup(L.Close)
+		addDynamic(L, context.Background(), client, mapper)
+	})
+
+	// run executes code and returns the global result as JSON-like value
+	run := func(code string) (any, error) {
+		if err := L.DoString(code); err != nil {
+			return nil, err
+		}
+		return luaValToJSON(L.GetGlobal("result"))
+	}
+
+	// stored returns the pod named name as stored by the fake client
+	stored := func(name string) (*unstructured.Unstructured, error) {
+		return client.Resource(pods).Namespace("default").Get(context.Background(), name, metav1.GetOptions{})
+	}
+
+	It("should get objects as tables", func() {
+		Expect(run(`result = dynamic.get("v1", "Pod", "web", "default").spec.containers[1].image`)).To(Equal("busybox"))
+		Expect(run(`result = dynamic.get("v1", "Namespace", "default", "ignored").metadata.name`)).To(Equal("default"))
+	})
+
+	It("should list objects by namespace and label selector", func() {
+		Expect(run(`result = #dynamic.list("v1", "Pod", "default").items`)).To(BeEquivalentTo(2))
+		Expect(run(`result = dynamic.list("v1", "Pod", "", {labelSelector = "app=db"}).items[1].metadata.name`)).To(Equal("db"))
+	})
+
+	It("should create, update and patch objects", func() {
+		Expect(run(`
+			local pod = dynamic.get("v1", "Pod", "web", "default")
+			pod.metadata = {name = "cache", namespace = "default"}
+			result = dynamic.create(pod).metadata.name
+		`)).To(Equal("cache"))
+		Expect(stored("cache")).NotTo(BeNil())
+
+		Expect(run(`
+			local pod = dynamic.get("v1", "Pod", "web", "default")
+			pod.metadata.labels.tier = "frontend"
+			result = dynamic.update(pod).metadata.labels.tier
+		`)).To(Equal("frontend"))
+
+		Expect(run(`result = dynamic.patch("v1", "Pod", "web", "default", {metadata = {labels = {tier = "backend"}}}).metadata.labels.tier`)).
+			To(Equal("backend"))
+		Expect(run(`result = dynamic.patch("v1", "Pod", "web", "default", '{"metadata":{"labels":{"tier":"edge"}}}').metadata.labels.tier`)).
+			To(Equal("edge"))
+	})
+
+	It("should keep empty arrays of objects written back", func() {
+		_, err := run(`
+			local pod = dynamic.get("v1", "Pod", "web", "default")
+			pod.metadata.labels.tier = "frontend"
+			dynamic.update(pod)
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		pod, err := stored("web")
+		Expect(err).NotTo(HaveOccurred())
+		containers, _, _ := unstructured.NestedSlice(pod.Object, "spec", "containers")
+		Expect(containers).To(HaveLen(1))
+		Expect(containers[0]).To(HaveKeyWithValue("args", []any{}))
+	})
+
+	It("should delete objects", func() {
+		Expect(run(`result = dynamic.delete("v1", "Pod", "web", "default")`)).To(BeTrue())
+		_, err := stored("web")
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	DescribeTable("should return nil and an error",
+		func(call string) {
+			Expect(run(`local obj, err = ` + call + `; result = {obj == nil, err ~= nil}`)).To(Equal([]any{true, true}))
+		},
+		Entry("for get of a missing object", `dynamic.get("v1", "Pod", "missing", "default")`),
+		Entry("for get of an unknown kind", `dynamic.get("v1", "Widget", "web", "default")`),
+		Entry("for list of an unknown kind", `dynamic.list("v1", "Widget")`),
+		Entry("for create of an existing object", `dynamic.create(dynamic.get("v1", "Pod", "web", "default"))`),
+		Entry("for patch of a missing object", `dynamic.patch("v1", "Pod", "missing", "default", {})`),
+		Entry("for delete of a missing object", `dynamic.delete("v1", "Pod", "missing", "default")`),
+		Entry("for delete of an unknown kind", `dynamic.delete("v1", "Widget", "web", "default")`),
+	)
+
+	It("should raise an error for invalid arguments", func() {
+		Expect(L.DoString(`dynamic.create({metadata = {name = "web"}})`)).To(MatchError(ContainSubstring("object must have apiVersion and kind")))
+		Expect(L.DoString(`dynamic.patch("v1", "Pod", "web", "default", {}, "yaml")`)).To(MatchError(ContainSubstring("patch type must be one of")))
+	})
+})
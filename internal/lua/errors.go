@@ -0,0 +1,117 @@
+package lua
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+var (
+	// runtime errors are prefixed with the chunk name and line, e.g. "<string>:3: attempt to call a nil value"
+	runtimeErrorLine = regexp.MustCompile(`^<string>:(\d+): `)
+	// moonscript parse errors point at the offending line, e.g. " [3] >>    x = "
+	moonErrorLine = regexp.MustCompile(`\[(\d+)\] >>`)
+)
+
+// ScriptError is returned by Exec and CompileMoonscript if a script
+// does not compile or raises an error at runtime.
+type ScriptError struct {
+	// Message is the error message without position and stack trace.
+	Message string
+	// Line is the line of the script the error refers to or 0 if unknown.
+	Line int
+	// Column is the column of the script the error refers to or 0 if unknown.
+	Column int
+	// Compile is true if the script could not be compiled.
+	Compile bool
+
+	err error
+	// Go error raised by the script, such as an error returned by the client
+	cause error
+}
+
+func (e *ScriptError) Error() string {
+	if e.err == nil {
+		return e.Message
+	}
+	return e.err.Error()
+}
+
+func (e *ScriptError) Unwrap() []error {
+	var errs []error
+	for _, err := range []error{e.err, e.cause} {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// newScriptError extracts message and position from an error raised by gopher-lua.
+func newScriptError(err error) *ScriptError {
+	scriptErr := &ScriptError{Message: err.Error(), err: err}
+
+	var apiErr *lua.ApiError
+	if !errors.As(err, &apiErr) {
+		return scriptErr
+	}
+
+	scriptErr.Compile = apiErr.Type == lua.ApiErrorSyntax
+	scriptErr.Message = strings.TrimSpace(apiErr.Object.String())
+	if cause := raisedError(apiErr.Object); cause != nil {
+		scriptErr.cause = cause
+		scriptErr.Message = cause.Error()
+		return scriptErr
+	}
+
+	var parseErr *parse.Error
+	if errors.As(apiErr.Cause, &parseErr) {
+		scriptErr.Message = parseErr.Message
+		if parseErr.Token != "" {
+			scriptErr.Message = fmt.Sprintf("%s near '%s'", parseErr.Message, parseErr.Token)
+		}
+		if parseErr.Pos.Line > 0 {
+			scriptErr.Line = parseErr.Pos.Line
+			scriptErr.Column = parseErr.Pos.Column
+		}
+		return scriptErr
+	}
+
+	if m := runtimeErrorLine.FindStringSubmatch(scriptErr.Message); m != nil {
+		scriptErr.Line, _ = strconv.Atoi(m[1])
+		scriptErr.Message = strings.TrimPrefix(scriptErr.Message, m[0])
+	}
+	return scriptErr
+}
+
+// raisedError returns the Go error passed to error() by a script, if any.
+// Errors returned by bound functions are converted to tables holding the
+// original value by goValToLua.
+func raisedError(value lua.LValue) error {
+	tbl, ok := value.(*lua.LTable)
+	if !ok {
+		return nil
+	}
+	for _, field := range []string{LUA_TABLE_PTR, LUA_TABLE_STRUCT} {
+		if ud, ok := tbl.RawGetString(field).(*lua.LUserData); ok {
+			if err, ok := ud.Value.(error); ok {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// newMoonscriptError wraps an error reported by the moonscript compiler.
+func newMoonscriptError(msg string) *ScriptError {
+	scriptErr := &ScriptError{Message: msg, Compile: true, err: errors.New(msg)}
+	if m := moonErrorLine.FindStringSubmatch(msg); m != nil {
+		scriptErr.Line, _ = strconv.Atoi(m[1])
+	}
+	return scriptErr
+}
@@ -2,7 +2,10 @@ package lua
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"reflect"
@@ -11,8 +14,12 @@ import (
 	lua "github.com/yuin/gopher-lua"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
@@ -31,51 +38,191 @@ func getKubeConfig() (*rest.Config, error) {
 	return clientcmd.BuildConfigFromFlags("", kubeconfig)
 }
 
-func Exec(ctx context.Context, code string, cli client.Client) error {
+// bindingGlobals are the globals defined by Exec in addition to the Lua standard library.
+var bindingGlobals = append([]string{"print", "log", "ctx", "discovery", "client", "dynamic"}, namespaceNames()...)
 
-	config, err := getKubeConfig()
-	if err != nil {
-		panic(err)
+// namespaceNames returns the names of the generated API group namespaces.
+func namespaceNames() []string {
+	names := make([]string, len(apiNamespaces))
+	for i, ns := range apiNamespaces {
+		names[i] = ns.name
 	}
+	return names
+}
+
+// Option configures a single execution of a script.
+type Option func(*execOptions)
+
+type execOptions struct {
+	globals       []global
+	moduleLoaders []ModuleLoader
+	limits        Limits
+	discovery     discovery.DiscoveryInterface
+	dynamic       dynamic.Interface
+	mapper        meta.RESTMapper
+	output        io.Writer
+}
+
+type global struct {
+	name     string
+	value    any
+	readOnly bool
+}
+
+// WithGlobal exposes a JSON-like value, as produced by encoding/json or
+// found in unstructured objects, to the script as a global variable.
+func WithGlobal(name string, value any) Option {
+	return func(o *execOptions) {
+		o.globals = append(o.globals, global{name: name, value: value})
+	}
+}
+
+// WithReadOnlyGlobal is like WithGlobal for a map whose keys cannot be assigned by the script.
+func WithReadOnlyGlobal(name string, value map[string]any) Option {
+	return func(o *execOptions) {
+		o.globals = append(o.globals, global{name: name, value: value, readOnly: true})
+	}
+}
+
+// WithDiscoveryClient exposes d to the script as discovery. Without it, a discovery
+// client is created from the kubeconfig of the process.
+func WithDiscoveryClient(d discovery.DiscoveryInterface) Option {
+	return func(o *execOptions) {
+		o.discovery = d
+	}
+}
+
+// WithOutput copies what the script prints through print and log to w,
+// in addition to the output of the process.
+func WithOutput(w io.Writer) Option {
+	return func(o *execOptions) {
+		o.output = w
+	}
+}
 
-	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
-	if err != nil {
-		panic(err)
+// Exec executes code and returns the values returned by it as JSON. A single
+// value is returned as is, multiple values as array and no value as nil.
+func Exec(ctx context.Context, code string, cli client.Client, opts ...Option) (json.RawMessage, error) {
+	var options execOptions
+	for _, opt := range opts {
+		opt(&options)
 	}
 
-	L := lua.NewState()
+	discoveryClient := options.discovery
+	dynamicClient, mapper := options.dynamic, options.mapper
+	if discoveryClient == nil || dynamicClient == nil {
+		config, err := getKubeConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed loading kubeconfig: %w", err)
+		}
+		if discoveryClient == nil {
+			if discoveryClient, err = discovery.NewDiscoveryClientForConfig(config); err != nil {
+				return nil, fmt.Errorf("failed creating discovery client: %w", err)
+			}
+		}
+		if dynamicClient == nil {
+			if dynamicClient, err = dynamic.NewForConfig(config); err != nil {
+				return nil, fmt.Errorf("failed creating dynamic client: %w", err)
+			}
+			mapper = restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+		}
+	}
+
+	L := newStateWithLimits(options.limits)
 	defer L.Close()
 
+	// abort execution when the context is cancelled
+	L.SetContext(ctx)
+
 	libs.Preload(L)
 
 	// add project assets
 	if err := L.DoString(`package.path = package.path .. 
 			";modules/lua/?.lua;modules/lua/?/init.lua"`); err != nil {
-		return err
+		return nil, err
+	}
+
+	if options.output == nil {
+		addFunction(L, nil, "print", reflect.ValueOf(fmt.Println))
+		addFunction(L, nil, "log", reflect.ValueOf(log.Printf))
+	} else {
+		stdout := io.MultiWriter(os.Stdout, options.output)
+		logger := log.New(io.MultiWriter(log.Writer(), options.output), log.Prefix(), log.Flags())
+		addFunction(L, nil, "print", reflect.ValueOf(func(a ...any) (int, error) {
+			return fmt.Fprintln(stdout, a...)
+		}))
+		addFunction(L, nil, "log", reflect.ValueOf(logger.Printf))
+	}
+
+	if err := addObject(L, "ctx", reflect.ValueOf(ctx), "context"); err != nil {
+		return nil, err
 	}
 
-	addFunction(L, nil, "print", reflect.ValueOf(fmt.Println))
-	addFunction(L, nil, "log", reflect.ValueOf(log.Printf))
+	if err := addObject(L, "discovery", reflect.ValueOf(discoveryClient), "k8s.io/client-go/discovery"); err != nil {
+		return nil, err
+	}
 
-	if err := addObject(L, "ctx", reflect.ValueOf(ctx)); err != nil {
-		return err
+	if err := addObject(L, "client", reflect.ValueOf(cli), "sigs.k8s.io/controller-runtime/pkg/client"); err != nil {
+		return nil, err
 	}
 
-	if err := addObject(L, "discovery", reflect.ValueOf(discoveryClient)); err != nil {
-		return err
+	addDynamic(L, ctx, dynamicClient, mapper)
+
+	for _, ns := range apiNamespaces {
+		addTypes(L, addNamespace(L, ns.name), ns.pkg)
 	}
 
-	if err := addObject(L, "client", reflect.ValueOf(cli)); err != nil {
-		return err
+	for _, loader := range options.moduleLoaders {
+		if err := addModuleLoader(L, loader); err != nil {
+			return nil, err
+		}
 	}
 
-	coreNs := addNamespace(L, "core")
-	addTypes(L, coreNs, "k8s.io/api/core/v1")
+	for _, g := range options.globals {
+		value := jsonValToLua(L, g.value)
+		if g.readOnly {
+			value = readOnlyTable(L, g.name, value.(*lua.LTable))
+		}
+		L.SetGlobal(g.name, value)
+	}
 
 	/*
 		_scheme := addNamespace(L, "scheme")
 		addObject(L, _scheme, scheme.Scheme)
 	*/
 
-	return L.DoString(code)
+	if options.limits.Instructions > 0 || options.limits.Memory > 0 {
+		limitCtx, cancel := withAccounting(ctx, L, options.limits)
+		defer cancel()
+		L.SetContext(limitCtx)
+	}
+
+	top := L.GetTop()
+	if err := L.DoString(code); err != nil {
+		var limitErr *LimitError
+		if errors.As(context.Cause(L.Context()), &limitErr) {
+			return nil, limitErr
+		}
+		if limitErr := limitError(err, options.limits); limitErr != nil {
+			return nil, limitErr
+		}
+		return nil, newScriptError(err)
+	}
+
+	values := make([]any, 0, L.GetTop()-top)
+	for i := top + 1; i <= L.GetTop(); i++ {
+		value, err := luaValToJSON(L.Get(i))
+		if err != nil {
+			return nil, fmt.Errorf("invalid return value %d: %w", i-top, err)
+		}
+		values = append(values, value)
+	}
+	switch len(values) {
+	case 0:
+		return nil, nil
+	case 1:
+		return json.Marshal(values[0])
+	default:
+		return json.Marshal(values)
+	}
 }
@@ -0,0 +1,330 @@
+package lua
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// minimum number of bytes allocated through the binding layer between two memory accounting passes
+const minAccountingInterval = 1 << 16
+
+// number of instructions executed before the first memory accounting pass, which
+// doubles with every pass until it reaches the bounded share of the execution time
+const minSampleInterval = 1 << 6
+
+// number of instructions executed per value visited by the last memory accounting pass
+// before the next one, so that accounting takes a bounded share of the execution time
+const sampleCost = 16
+
+// vmLoop is the function of gopher-lua that executes instructions.
+const vmLoop = "github.com/yuin/gopher-lua.mainLoopWithContext"
+
+// approximate sizes of Lua values in bytes
+const (
+	valueSize    = 16
+	tableSize    = 64
+	entrySize    = 40
+	functionSize = 64
+	userDataSize = 64
+)
+
+// Limits restricts the resources a single execution may use. Zero values
+// fall back to the defaults of gopher-lua or mean unlimited.
+type Limits struct {
+	// CallStackSize is the maximum depth of nested function calls.
+	CallStackSize int
+	// RegistrySize is the maximum number of values on the Lua stack.
+	RegistrySize int
+	// Instructions is the maximum number of Lua instructions executed.
+	Instructions int64
+	// Memory is the maximum approximate size in bytes of the values allocated by the script.
+	Memory int64
+}
+
+// WithLimits restricts the resources an execution may use.
+func WithLimits(limits Limits) Option {
+	return func(o *execOptions) {
+		o.limits = limits
+	}
+}
+
+// LimitError is returned by Exec if an execution exceeded one of its limits.
+type LimitError struct {
+	// Limit is the name of the exceeded limit.
+	Limit string
+	// Value is the configured limit.
+	Value int64
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s limit of %d exceeded", e.Limit, e.Value)
+}
+
+// newStateWithLimits creates a Lua state restricted by limits.
+func newStateWithLimits(limits Limits) *lua.LState {
+	opts := lua.Options{
+		CallStackSize: limits.CallStackSize,
+	}
+	if limits.RegistrySize > 0 {
+		opts.RegistrySize = min(lua.RegistrySize, limits.RegistrySize)
+		opts.RegistryMaxSize = limits.RegistrySize
+	}
+	return lua.NewState(opts)
+}
+
+// limitError converts errors raised by gopher-lua for exceeded stack limits to LimitError.
+func limitError(err error, limits Limits) error {
+	var apiErr *lua.ApiError
+	if !errors.As(err, &apiErr) || apiErr.Object == nil {
+		return nil
+	}
+	msg := apiErr.Object.String()
+	switch {
+	case strings.Contains(msg, "stack overflow"):
+		return &LimitError{Limit: "call stack size", Value: int64(orDefault(limits.CallStackSize, lua.CallStackSize))}
+	case strings.Contains(msg, "registry overflow"):
+		return &LimitError{Limit: "registry size", Value: int64(orDefault(limits.RegistrySize, lua.RegistrySize))}
+	}
+	return nil
+}
+
+// orDefault returns limit if set or the default of gopher-lua otherwise.
+func orDefault(limit, def int) int {
+	if limit > 0 {
+		return limit
+	}
+	return def
+}
+
+// accountingKey is the context key of the accounting of a Lua state.
+type accountingKey struct{}
+
+// accounting is the context of a Lua state with instruction or memory limits,
+// which is cancelled with a LimitError once a limit is exceeded. gopher-lua checks
+// Done before every instruction, which counts the instructions executed. Memory
+// is accounted where values cross the binding layer, i.e. when Go values are
+// converted to Lua values and by the string and table functions that build new
+// strings, and every few instructions for the values built by Lua itself, such as
+// concatenated strings and tables. Accounting estimates the size of the values
+// reachable from the state, which is only safe on the goroutine executing it.
+type accounting struct {
+	context.Context
+	cancel context.CancelCauseFunc
+
+	L            *lua.LState
+	instructions atomic.Int64
+	limits       Limits
+
+	// size of the values reachable before the script was executed
+	baseline int64
+	// size of the values allocated by the script as of the last estimate
+	used int64
+	// bytes allocated since the last estimate and before the next one
+	allocated int64
+	nextPass  int64
+	// number of instructions executed before the next estimate and between the last two
+	nextSample     atomic.Int64
+	sampleInterval int64
+}
+
+// withAccounting returns a context for L that enforces the instruction and memory
+// limits. It must be called after the bindings have been added to L.
+func withAccounting(ctx context.Context, L *lua.LState, limits Limits) (*accounting, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	a := &accounting{
+		Context:        ctx,
+		cancel:         cancel,
+		L:              L,
+		limits:         limits,
+		nextPass:       minAccountingInterval,
+		sampleInterval: minSampleInterval,
+	}
+	if limits.Memory > 0 {
+		a.baseline, _ = estimateSize(L)
+		a.nextSample.Store(minSampleInterval)
+		accountStringFunctions(L)
+	}
+	return a, func() { cancel(nil) }
+}
+
+func (a *accounting) Done() <-chan struct{} {
+	n := a.instructions.Add(1)
+	if a.limits.Instructions > 0 && n > a.limits.Instructions {
+		a.cancel(&LimitError{Limit: "instructions", Value: a.limits.Instructions})
+	}
+	// Done is also called by goroutines of the libraries waiting for the execution to end
+	if a.limits.Memory > 0 && n >= a.nextSample.Load() && calledBy(vmLoop) {
+		_ = a.checkMemory(0)
+	}
+	return a.Context.Done()
+}
+
+func (a *accounting) Value(key any) any {
+	if key == (accountingKey{}) {
+		return a
+	}
+	return a.Context.Value(key)
+}
+
+// checkMemory estimates the values reachable from the state and schedules the
+// next estimate. It cancels the execution and returns a LimitError if the values
+// allocated by the script and n further bytes exceed the memory limit.
+func (a *accounting) checkMemory(n int64) error {
+	size, values := estimateSize(a.L)
+	a.used = max(0, size-a.baseline)
+	a.allocated = 0
+	a.nextPass = max(minAccountingInterval, min(a.used/2, a.limits.Memory-a.used))
+	a.sampleInterval = min(2*a.sampleInterval, max(minSampleInterval, sampleCost*values))
+	a.nextSample.Store(a.instructions.Load() + a.sampleInterval)
+	if a.used+n > a.limits.Memory {
+		err := &LimitError{Limit: "memory", Value: a.limits.Memory}
+		a.cancel(err)
+		return err
+	}
+	return nil
+}
+
+// calledBy returns true if the caller of the calling function is fn.
+func calledBy(fn string) bool {
+	var pcs [1]uintptr
+	if runtime.Callers(3, pcs[:]) == 0 {
+		return false
+	}
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+	return frame.Function == fn
+}
+
+// allocate accounts n bytes about to be allocated for the script by the binding
+// layer of L, raising a LimitError once the memory limit would be exceeded.
+// The reachable values are estimated whenever enough has been allocated since
+// the last estimate, so that accounting takes a bounded share of the execution time.
+func allocate(L *lua.LState, n int64) {
+	ctx := L.Context()
+	if ctx == nil {
+		return
+	}
+	a, ok := ctx.Value(accountingKey{}).(*accounting)
+	if !ok || a.limits.Memory <= 0 {
+		return
+	}
+
+	a.allocated += n
+	if a.allocated < a.nextPass && a.used+n <= a.limits.Memory {
+		return
+	}
+	if err := a.checkMemory(n); err != nil {
+		L.RaiseError("%s", err)
+	}
+}
+
+// accountStringFunctions replaces the functions of the string and table libraries
+// that build new strings by functions that account their results. The size of
+// the results of string.rep is accounted before they are built.
+func accountStringFunctions(L *lua.LState) {
+	account := func(fn lua.LGFunction) lua.LGFunction {
+		return func(L *lua.LState) int {
+			n := fn(L)
+			if n > 0 {
+				if s, ok := L.Get(-n).(lua.LString); ok {
+					allocate(L, valueSize+int64(len(s)))
+				}
+			}
+			return n
+		}
+	}
+
+	str := L.GetGlobal("string").(*lua.LTable)
+	for _, name := range []string{"format", "gsub", "lower", "upper", "reverse"} {
+		str.RawSetString(name, L.NewFunction(account(str.RawGetString(name).(*lua.LFunction).GFunction)))
+	}
+	rep := str.RawGetString("rep").(*lua.LFunction).GFunction
+	str.RawSetString("rep", L.NewFunction(func(L *lua.LState) int {
+		if n := int64(L.CheckInt(2)); n > 0 {
+			allocate(L, valueSize+n*int64(len(L.CheckString(1))))
+		}
+		return rep(L)
+	}))
+
+	table := L.GetGlobal("table").(*lua.LTable)
+	table.RawSetString("concat", L.NewFunction(account(table.RawGetString("concat").(*lua.LFunction).GFunction)))
+}
+
+// estimateSize returns the approximate size in bytes and the number of the values
+// reachable from the globals, the registry and the locals of all functions being executed.
+func estimateSize(L *lua.LState) (int64, int64) {
+	e := &sizeEstimate{visited: make(map[any]struct{})}
+	e.add(L.G.Global)
+	e.add(L.G.Registry)
+	for level := 0; ; level++ {
+		dbg, ok := L.GetStack(level)
+		if !ok {
+			break
+		}
+		for i := 1; ; i++ {
+			name, value := L.GetLocal(dbg, i)
+			if name == "" {
+				break
+			}
+			e.add(value)
+		}
+	}
+	return e.size, e.values
+}
+
+type sizeEstimate struct {
+	size    int64
+	values  int64
+	visited map[any]struct{}
+}
+
+func (e *sizeEstimate) add(value lua.LValue) {
+	e.values++
+	switch v := value.(type) {
+	case lua.LString:
+		e.size += valueSize + int64(len(v))
+	case *lua.LTable:
+		if e.visit(v) {
+			e.size += tableSize
+			v.ForEach(func(key, value lua.LValue) {
+				e.size += entrySize
+				e.add(key)
+				e.add(value)
+			})
+			e.add(v.Metatable)
+		}
+	case *lua.LFunction:
+		if e.visit(v) {
+			e.size += functionSize
+			e.add(v.Env)
+			for _, upvalue := range v.Upvalues {
+				if upvalue != nil {
+					e.add(upvalue.Value())
+				}
+			}
+		}
+	case *lua.LUserData:
+		if e.visit(v) {
+			e.size += userDataSize
+			e.add(v.Env)
+			e.add(v.Metatable)
+		}
+	case nil:
+	default:
+		e.size += valueSize
+	}
+}
+
+// visit returns true if value has not been visited before.
+func (e *sizeEstimate) visit(value any) bool {
+	if _, ok := e.visited[value]; ok {
+		return false
+	}
+	e.visited[value] = struct{}{}
+	return true
+}
@@ -0,0 +1,100 @@
+package lua
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Limits", func() {
+	// exec executes code restricted by limits
+	exec := func(code string, limits Limits) (json.RawMessage, error) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		return Exec(ctx, code, fake.NewClientBuilder().Build(),
+			WithLimits(limits),
+			WithDiscoveryClient(kubefake.NewSimpleClientset().Discovery()),
+			WithDynamicClient(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), meta.NewDefaultRESTMapper(nil)))
+	}
+
+	DescribeTable("should terminate executions exceeding a limit",
+		func(code string, limits Limits, expected *LimitError) {
+			_, err := exec(code, limits)
+			Expect(err).To(Equal(expected))
+		},
+		Entry("instructions", `while true do end`,
+			Limits{Instructions: 10000}, &LimitError{Limit: "instructions", Value: 10000}),
+		Entry("call stack size", `local function f(n) return 1 + f(n + 1) end; f(1)`,
+			Limits{CallStackSize: 64}, &LimitError{Limit: "call stack size", Value: 64}),
+		Entry("registry size", `local t = {}; for i = 1, 10000 do t[i] = i end; return unpack(t)`,
+			Limits{RegistrySize: 1024}, &LimitError{Limit: "registry size", Value: 1024}),
+		Entry("memory of a single string", `local s = string.rep("x", 1e9)`,
+			Limits{Memory: 1 << 20}, &LimitError{Limit: "memory", Value: 1 << 20}),
+		Entry("memory of strings built by the string library", `
+			local t = {}
+			for i = 1, 1e6 do t[i] = string.format("%s-%d", string.rep("x", 100), i) end`,
+			Limits{Memory: 1 << 20}, &LimitError{Limit: "memory", Value: 1 << 20}),
+		Entry("memory of strings built by table.concat", `
+			local t = {}
+			for i = 1, 1000 do t[i] = "x" end
+			local s = {}
+			for i = 1, 1e6 do s[i] = table.concat(t) end`,
+			Limits{Memory: 1 << 20}, &LimitError{Limit: "memory", Value: 1 << 20}),
+		Entry("memory of Go values", `
+			local t = {}
+			for i = 1, 1e6 do t[i] = core.Pod:new() end`,
+			Limits{Memory: 1 << 20}, &LimitError{Limit: "memory", Value: 1 << 20}),
+		Entry("memory of a string doubled by concatenation", `
+			local s = "x"
+			for i = 1, 27 do s = s .. s end
+			return #s`,
+			Limits{Memory: 1 << 20}, &LimitError{Limit: "memory", Value: 1 << 20}),
+		Entry("memory of strings built by concatenation", `
+			local t = {}
+			for i = 1, 1e7 do t[i] = "key-" .. i end`,
+			Limits{Memory: 1 << 20}, &LimitError{Limit: "memory", Value: 1 << 20}),
+		Entry("memory of tables", `
+			local t = {}
+			for i = 1, 3e6 do t[i] = {} end`,
+			Limits{Memory: 1 << 20}, &LimitError{Limit: "memory", Value: 1 << 20}),
+		Entry("memory of nested tables in globals", `
+			t = {}
+			for i = 1, 3e6 do t[i] = {n = i, {i}} end`,
+			Limits{Memory: 1 << 20}, &LimitError{Limit: "memory", Value: 1 << 20}),
+		Entry("memory despite pcall", `
+			local t = {}
+			for i = 1, 1e6 do pcall(function() t[i] = string.rep("x", 1024) end) end`,
+			Limits{Memory: 1 << 20}, &LimitError{Limit: "memory", Value: 1 << 20}),
+	)
+
+	DescribeTable("should complete executions within their limits",
+		func(code string, limits Limits) {
+			Expect(exec(code, limits)).To(BeEquivalentTo(`1000`))
+		},
+		Entry("instructions", `local n = 0; for i = 1, 1000 do n = n + 1 end; return n`,
+			Limits{Instructions: 1e5}),
+		Entry("memory of garbage", `
+			local n = 0
+			for i = 1, 1000 do n = n + #string.rep("x", 10000) / 10000 end
+			return n`,
+			Limits{Memory: 1 << 20}),
+		Entry("memory of Go values", `
+			local t = {}
+			for i = 1, 1000 do t[i] = core.Pod:new() end
+			return #t`,
+			Limits{Memory: 64 << 20}),
+		Entry("memory of tables", `
+			local t = {}
+			for i = 1, 1000 do t[i] = {i} end
+			return #t`,
+			Limits{Memory: 1 << 20}),
+	)
+})
@@ -0,0 +1,49 @@
+package lua
+
+import (
+	"fmt"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// ModuleLoader returns the Lua code of the module required by name.
+// found is false if the loader does not know the module.
+type ModuleLoader func(name string) (code string, found bool, err error)
+
+// WithModuleLoader makes modules of loader available to require. The loader is
+// consulted after package.preload and before package.path.
+func WithModuleLoader(loader ModuleLoader) Option {
+	return func(o *execOptions) {
+		o.moduleLoaders = append(o.moduleLoaders, loader)
+	}
+}
+
+// addModuleLoader registers loader as entry of package.loaders, the Lua 5.1
+// equivalent of package.searchers.
+func addModuleLoader(L *lua.LState, loader ModuleLoader) error {
+	loaders, ok := L.GetField(L.GetGlobal("package"), "loaders").(*lua.LTable)
+	if !ok {
+		return fmt.Errorf("package.loaders is not available")
+	}
+
+	loaders.Insert(2, L.NewFunction(func(L *lua.LState) int {
+		name := L.CheckString(1)
+		code, found, err := loader(name)
+		if err != nil {
+			L.RaiseError("error loading module '%s': %v", name, err)
+		}
+		if !found {
+			L.Push(lua.LString(fmt.Sprintf("\n\tno module '%s' in cluster", name)))
+			return 1
+		}
+		// the chunk is named after the module, so that errors do not refer to lines of the script
+		fn, err := L.Load(strings.NewReader(code), name)
+		if err != nil {
+			L.RaiseError("error loading module '%s': %v", name, err)
+		}
+		L.Push(fn)
+		return 1
+	}))
+	return nil
+}
@@ -1,8 +1,6 @@
 package lua
 
 import (
-	"fmt"
-
 	lua "github.com/yuin/gopher-lua"
 )
 
@@ -55,7 +53,7 @@ return luaCode, nil
 	L.Pop(2)
 
 	if luaErr != lua.LNil {
-		return "", fmt.Errorf("%s", luaErr)
+		return "", newMoonscriptError(luaErr.String())
 	}
 
 	return luaCode.String(), nil
@@ -0,0 +1,20 @@
+package lua
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Registry", func() {
+	It("should register the types of every API namespace", func() {
+		Expect(namespaceNames()).To(ContainElements("core", "apps", "batch", "scripts"))
+		for _, ns := range apiNamespaces {
+			Expect(GetRegistry().Package(ns.pkg)).NotTo(BeEmpty(), "namespace %s", ns.name)
+		}
+	})
+
+	It("should register the functions of the packages of objects", func() {
+		Expect(GetRegistry().Package("context")).To(HaveKey("Background"))
+		Expect(GetRegistry().Package("sigs.k8s.io/controller-runtime/pkg/client")).To(HaveKey("ObjectKey"))
+	})
+})
@@ -0,0 +1,14 @@
+package lua
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestLua(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Lua Suite")
+}
@@ -0,0 +1,225 @@
+package lua
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/ast"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+// UndefinedGlobal is a global read by a script that is defined neither by the script nor by Exec.
+type UndefinedGlobal struct {
+	Name string
+	// Line is the line of the first reference.
+	Line int
+}
+
+// execGlobals are the names of the globals defined by Exec, including the Lua standard library.
+var execGlobals = sync.OnceValue(func() map[string]struct{} {
+	L := lua.NewState()
+	defer L.Close()
+
+	globals := make(map[string]struct{})
+	L.G.Global.ForEach(func(key, _ lua.LValue) {
+		if name, ok := key.(lua.LString); ok {
+			globals[string(name)] = struct{}{}
+		}
+	})
+	for _, name := range bindingGlobals {
+		globals[name] = struct{}{}
+	}
+	return globals
+})
+
+// Parse parses Lua code without executing it. Syntax errors are returned as ScriptError.
+func Parse(code string) error {
+	_, err := parseChunk(code)
+	return err
+}
+
+// UndefinedGlobals parses Lua code and returns the globals it reads that are neither
+// assigned by the code, defined by Exec nor among globals, in the order of their first reference.
+func UndefinedGlobals(code string, globals ...string) ([]UndefinedGlobal, error) {
+	chunk, err := parseChunk(code)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &globalsWalker{assigned: make(map[string]struct{}), read: make(map[string]int)}
+	w.block(chunk)
+
+	var undefined []UndefinedGlobal
+	for _, name := range w.order {
+		if _, ok := w.assigned[name]; ok {
+			continue
+		}
+		if _, ok := execGlobals()[name]; ok {
+			continue
+		}
+		if slices.Contains(globals, name) {
+			continue
+		}
+		undefined = append(undefined, UndefinedGlobal{Name: name, Line: w.read[name]})
+	}
+	return undefined, nil
+}
+
+func parseChunk(code string) ([]ast.Stmt, error) {
+	chunk, err := parse.Parse(strings.NewReader(code), "<string>")
+	if err != nil {
+		return nil, newScriptError(&lua.ApiError{Type: lua.ApiErrorSyntax, Object: lua.LString(err.Error()), Cause: err})
+	}
+	return chunk, nil
+}
+
+// globalsWalker collects the globals assigned and read by a chunk.
+type globalsWalker struct {
+	// local variables by scope, innermost last
+	scopes   []map[string]struct{}
+	assigned map[string]struct{}
+	// first line a global is read on
+	read  map[string]int
+	order []string
+}
+
+func (w *globalsWalker) isLocal(name string) bool {
+	for i := len(w.scopes) - 1; i >= 0; i-- {
+		if _, ok := w.scopes[i][name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *globalsWalker) declare(names ...string) {
+	scope := w.scopes[len(w.scopes)-1]
+	for _, name := range names {
+		scope[name] = struct{}{}
+	}
+}
+
+// block walks statements in a new scope, declaring names in it first.
+func (w *globalsWalker) block(stmts []ast.Stmt, names ...string) {
+	w.scopes = append(w.scopes, make(map[string]struct{}))
+	w.declare(names...)
+	for _, stmt := range stmts {
+		w.stmt(stmt)
+	}
+	w.scopes = w.scopes[:len(w.scopes)-1]
+}
+
+func (w *globalsWalker) stmt(stmt ast.Stmt) {
+	switch s := stmt.(type) {
+	case *ast.AssignStmt:
+		w.exprs(s.Rhs)
+		for _, lhs := range s.Lhs {
+			w.assign(lhs)
+		}
+	case *ast.LocalAssignStmt:
+		// local function f() may refer to itself
+		if len(s.Names) == 1 && len(s.Exprs) == 1 {
+			if _, ok := s.Exprs[0].(*ast.FunctionExpr); ok {
+				w.declare(s.Names...)
+			}
+		}
+		w.exprs(s.Exprs)
+		w.declare(s.Names...)
+	case *ast.FuncCallStmt:
+		w.expr(s.Expr)
+	case *ast.DoBlockStmt:
+		w.block(s.Stmts)
+	case *ast.WhileStmt:
+		w.expr(s.Condition)
+		w.block(s.Stmts)
+	case *ast.RepeatStmt:
+		// the condition is evaluated in the scope of the body
+		w.scopes = append(w.scopes, make(map[string]struct{}))
+		for _, stmt := range s.Stmts {
+			w.stmt(stmt)
+		}
+		w.expr(s.Condition)
+		w.scopes = w.scopes[:len(w.scopes)-1]
+	case *ast.IfStmt:
+		w.expr(s.Condition)
+		w.block(s.Then)
+		w.block(s.Else)
+	case *ast.NumberForStmt:
+		w.exprs([]ast.Expr{s.Init, s.Limit, s.Step})
+		w.block(s.Stmts, s.Name)
+	case *ast.GenericForStmt:
+		w.exprs(s.Exprs)
+		w.block(s.Stmts, s.Names...)
+	case *ast.FuncDefStmt:
+		if s.Name.Receiver != nil {
+			// methods have the implicit parameter self
+			w.expr(s.Name.Receiver)
+			w.block(s.Func.Stmts, append([]string{"self"}, s.Func.ParList.Names...)...)
+		} else {
+			w.assign(s.Name.Func)
+			w.expr(s.Func)
+		}
+	case *ast.ReturnStmt:
+		w.exprs(s.Exprs)
+	}
+}
+
+// assign walks the target of an assignment.
+func (w *globalsWalker) assign(expr ast.Expr) {
+	if ident, ok := expr.(*ast.IdentExpr); ok {
+		if !w.isLocal(ident.Value) {
+			w.assigned[ident.Value] = struct{}{}
+		}
+		return
+	}
+	w.expr(expr)
+}
+
+func (w *globalsWalker) exprs(exprs []ast.Expr) {
+	for _, expr := range exprs {
+		w.expr(expr)
+	}
+}
+
+func (w *globalsWalker) expr(expr ast.Expr) {
+	switch e := expr.(type) {
+	case *ast.IdentExpr:
+		if w.isLocal(e.Value) {
+			return
+		}
+		if _, ok := w.read[e.Value]; !ok {
+			w.read[e.Value] = e.Line()
+			w.order = append(w.order, e.Value)
+		}
+	case *ast.AttrGetExpr:
+		w.expr(e.Object)
+		w.expr(e.Key)
+	case *ast.TableExpr:
+		for _, field := range e.Fields {
+			w.expr(field.Key)
+			w.expr(field.Value)
+		}
+	case *ast.FuncCallExpr:
+		w.expr(e.Func)
+		w.expr(e.Receiver)
+		w.exprs(e.Args)
+	case *ast.LogicalOpExpr:
+		w.exprs([]ast.Expr{e.Lhs, e.Rhs})
+	case *ast.RelationalOpExpr:
+		w.exprs([]ast.Expr{e.Lhs, e.Rhs})
+	case *ast.StringConcatOpExpr:
+		w.exprs([]ast.Expr{e.Lhs, e.Rhs})
+	case *ast.ArithmeticOpExpr:
+		w.exprs([]ast.Expr{e.Lhs, e.Rhs})
+	case *ast.UnaryMinusOpExpr:
+		w.expr(e.Expr)
+	case *ast.UnaryNotOpExpr:
+		w.expr(e.Expr)
+	case *ast.UnaryLenOpExpr:
+		w.expr(e.Expr)
+	case *ast.FunctionExpr:
+		w.block(e.Stmts, e.ParList.Names...)
+	}
+}
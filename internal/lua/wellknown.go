@@ -0,0 +1,109 @@
+package lua
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var (
+	timeType        = reflect.TypeOf(metav1.Time{})
+	microTimeType   = reflect.TypeOf(metav1.MicroTime{})
+	quantityType    = reflect.TypeOf(resource.Quantity{})
+	intOrStringType = reflect.TypeOf(intstr.IntOrString{})
+)
+
+// isWellKnown reports whether values of typ are converted to scalars rather than
+// tables, in the same representation as in the JSON of Kubernetes objects.
+func isWellKnown(typ reflect.Type) bool {
+	switch typ {
+	case timeType, microTimeType, quantityType, intOrStringType:
+		return true
+	}
+	return false
+}
+
+// wellKnownToLua converts a value of a well-known type to a Lua string or number.
+// Zero timestamps become nil.
+func wellKnownToLua(val reflect.Value) lua.LValue {
+	switch v := val.Interface().(type) {
+	case metav1.Time:
+		if v.IsZero() {
+			return lua.LNil
+		}
+		return lua.LString(v.UTC().Format(time.RFC3339))
+	case metav1.MicroTime:
+		if v.IsZero() {
+			return lua.LNil
+		}
+		return lua.LString(v.UTC().Format(metav1.RFC3339Micro))
+	case resource.Quantity:
+		return lua.LString(v.String())
+	case intstr.IntOrString:
+		if v.Type == intstr.Int {
+			return lua.LNumber(v.IntVal)
+		}
+		return lua.LString(v.StrVal)
+	}
+	return lua.LNil
+}
+
+// luaToWellKnown converts a Lua string or number to a value of a well-known type.
+func luaToWellKnown(val lua.LValue, typ reflect.Type) (reflect.Value, error) {
+	var result any
+	switch typ {
+	case timeType, microTimeType:
+		s, ok := val.(lua.LString)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("expected timestamp string, got %s", val.Type())
+		}
+		layout := time.RFC3339
+		if typ == microTimeType {
+			layout = metav1.RFC3339Micro
+		}
+		t, err := time.Parse(layout, string(s))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if typ == microTimeType {
+			result = metav1.NewMicroTime(t)
+		} else {
+			result = metav1.NewTime(t)
+		}
+	case quantityType:
+		var str string
+		switch v := val.(type) {
+		case lua.LString:
+			str = string(v)
+		case lua.LNumber:
+			str = strconv.FormatFloat(float64(v), 'f', -1, 64)
+		default:
+			return reflect.Value{}, fmt.Errorf("expected quantity, got %s", val.Type())
+		}
+		q, err := resource.ParseQuantity(str)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result = q
+	case intOrStringType:
+		switch v := val.(type) {
+		case lua.LString:
+			result = intstr.FromString(string(v))
+		case lua.LNumber:
+			i, err := toInt(v, 32)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			result = intstr.FromInt32(int32(i))
+		default:
+			return reflect.Value{}, fmt.Errorf("expected number or string, got %s", val.Type())
+		}
+	}
+	return reflect.ValueOf(result), nil
+}
@@ -0,0 +1,745 @@
+// Code generated by registry-gen. DO NOT EDIT.
+
+package lua
+
+import (
+	"context"
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	internalv1alpha1 "k8s.io/api/apiserverinternal/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	flowcontrolv1 "k8s.io/api/flowcontrol/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	nodev1 "k8s.io/api/node/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	resourcev1beta1 "k8s.io/api/resource/v1beta1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	storagemigrationv1alpha1 "k8s.io/api/storagemigration/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// apiNamespaces are the namespaces of the API groups exposed to scripts.
+var apiNamespaces = []apiNamespace{
+	{name: "admissionregistration", pkg: "k8s.io/api/admissionregistration/v1"},
+	{name: "apps", pkg: "k8s.io/api/apps/v1"},
+	{name: "authentication", pkg: "k8s.io/api/authentication/v1"},
+	{name: "authorization", pkg: "k8s.io/api/authorization/v1"},
+	{name: "autoscaling", pkg: "k8s.io/api/autoscaling/v2"},
+	{name: "batch", pkg: "k8s.io/api/batch/v1"},
+	{name: "certificates", pkg: "k8s.io/api/certificates/v1"},
+	{name: "coordination", pkg: "k8s.io/api/coordination/v1"},
+	{name: "core", pkg: "k8s.io/api/core/v1"},
+	{name: "discoveryv1", pkg: "k8s.io/api/discovery/v1"},
+	{name: "events", pkg: "k8s.io/api/events/v1"},
+	{name: "extensions", pkg: "k8s.io/api/extensions/v1beta1"},
+	{name: "flowcontrol", pkg: "k8s.io/api/flowcontrol/v1"},
+	{name: "internal", pkg: "k8s.io/api/apiserverinternal/v1alpha1"},
+	{name: "networking", pkg: "k8s.io/api/networking/v1"},
+	{name: "node", pkg: "k8s.io/api/node/v1"},
+	{name: "policy", pkg: "k8s.io/api/policy/v1"},
+	{name: "rbac", pkg: "k8s.io/api/rbac/v1"},
+	{name: "resource", pkg: "k8s.io/api/resource/v1beta1"},
+	{name: "scheduling", pkg: "k8s.io/api/scheduling/v1"},
+	{name: "scripts", pkg: "github.com/veith4f/scropt/api/v1"},
+	{name: "storage", pkg: "k8s.io/api/storage/v1"},
+	{name: "storagemigration", pkg: "k8s.io/api/storagemigration/v1alpha1"},
+}
+
+// registeredPackages are the structs and functions added to the registry by package and name.
+var registeredPackages = map[string]map[string]any{
+	"context": {
+		"AfterFunc":         context.AfterFunc,
+		"Background":        context.Background,
+		"Cause":             context.Cause,
+		"TODO":              context.TODO,
+		"WithCancel":        context.WithCancel,
+		"WithCancelCause":   context.WithCancelCause,
+		"WithDeadline":      context.WithDeadline,
+		"WithDeadlineCause": context.WithDeadlineCause,
+		"WithTimeout":       context.WithTimeout,
+		"WithTimeoutCause":  context.WithTimeoutCause,
+		"WithValue":         context.WithValue,
+		"WithoutCancel":     context.WithoutCancel,
+	},
+	"sigs.k8s.io/controller-runtime/pkg/client": {
+		"CacheOptions":                &client.CacheOptions{},
+		"CreateOptions":               &client.CreateOptions{},
+		"DeleteAllOfOptions":          &client.DeleteAllOfOptions{},
+		"DeleteOptions":               &client.DeleteOptions{},
+		"GetOptions":                  &client.GetOptions{},
+		"IgnoreAlreadyExists":         client.IgnoreAlreadyExists,
+		"IgnoreNotFound":              client.IgnoreNotFound,
+		"ListOptions":                 &client.ListOptions{},
+		"MatchingFieldsSelector":      &client.MatchingFieldsSelector{},
+		"MatchingLabelsSelector":      &client.MatchingLabelsSelector{},
+		"MergeFrom":                   client.MergeFrom,
+		"MergeFromOptions":            &client.MergeFromOptions{},
+		"MergeFromWithOptimisticLock": &client.MergeFromWithOptimisticLock{},
+		"MergeFromWithOptions":        client.MergeFromWithOptions,
+		"New":                         client.New,
+		"NewDryRunClient":             client.NewDryRunClient,
+		"NewNamespacedClient":         client.NewNamespacedClient,
+		"NewWithWatch":                client.NewWithWatch,
+		"ObjectKey":                   &client.ObjectKey{},
+		"ObjectKeyFromObject":         client.ObjectKeyFromObject,
+		"Options":                     &client.Options{},
+		"PatchOptions":                &client.PatchOptions{},
+		"Preconditions":               &client.Preconditions{},
+		"RawPatch":                    client.RawPatch,
+		"StrategicMergeFrom":          client.StrategicMergeFrom,
+		"SubResourceCreateOptions":    &client.SubResourceCreateOptions{},
+		"SubResourceGetOptions":       &client.SubResourceGetOptions{},
+		"SubResourcePatchOptions":     &client.SubResourcePatchOptions{},
+		"SubResourceUpdateOptions":    &client.SubResourceUpdateOptions{},
+		"UpdateOptions":               &client.UpdateOptions{},
+		"WithFieldOwner":              client.WithFieldOwner,
+		"WithFieldValidation":         client.WithFieldValidation,
+		"WithSubResourceBody":         client.WithSubResourceBody,
+	},
+	"k8s.io/api/admissionregistration/v1": {
+		"AuditAnnotation":                      &admissionregistrationv1.AuditAnnotation{},
+		"ExpressionWarning":                    &admissionregistrationv1.ExpressionWarning{},
+		"MatchCondition":                       &admissionregistrationv1.MatchCondition{},
+		"MatchResources":                       &admissionregistrationv1.MatchResources{},
+		"MutatingWebhook":                      &admissionregistrationv1.MutatingWebhook{},
+		"MutatingWebhookConfiguration":         &admissionregistrationv1.MutatingWebhookConfiguration{},
+		"MutatingWebhookConfigurationList":     &admissionregistrationv1.MutatingWebhookConfigurationList{},
+		"NamedRuleWithOperations":              &admissionregistrationv1.NamedRuleWithOperations{},
+		"ParamKind":                            &admissionregistrationv1.ParamKind{},
+		"ParamRef":                             &admissionregistrationv1.ParamRef{},
+		"Rule":                                 &admissionregistrationv1.Rule{},
+		"RuleWithOperations":                   &admissionregistrationv1.RuleWithOperations{},
+		"ServiceReference":                     &admissionregistrationv1.ServiceReference{},
+		"TypeChecking":                         &admissionregistrationv1.TypeChecking{},
+		"ValidatingAdmissionPolicy":            &admissionregistrationv1.ValidatingAdmissionPolicy{},
+		"ValidatingAdmissionPolicyBinding":     &admissionregistrationv1.ValidatingAdmissionPolicyBinding{},
+		"ValidatingAdmissionPolicyBindingList": &admissionregistrationv1.ValidatingAdmissionPolicyBindingList{},
+		"ValidatingAdmissionPolicyBindingSpec": &admissionregistrationv1.ValidatingAdmissionPolicyBindingSpec{},
+		"ValidatingAdmissionPolicyList":        &admissionregistrationv1.ValidatingAdmissionPolicyList{},
+		"ValidatingAdmissionPolicySpec":        &admissionregistrationv1.ValidatingAdmissionPolicySpec{},
+		"ValidatingAdmissionPolicyStatus":      &admissionregistrationv1.ValidatingAdmissionPolicyStatus{},
+		"ValidatingWebhook":                    &admissionregistrationv1.ValidatingWebhook{},
+		"ValidatingWebhookConfiguration":       &admissionregistrationv1.ValidatingWebhookConfiguration{},
+		"ValidatingWebhookConfigurationList":   &admissionregistrationv1.ValidatingWebhookConfigurationList{},
+		"Validation":                           &admissionregistrationv1.Validation{},
+		"Variable":                             &admissionregistrationv1.Variable{},
+		"WebhookClientConfig":                  &admissionregistrationv1.WebhookClientConfig{},
+	},
+	"k8s.io/api/apps/v1": {
+		"ControllerRevision":               &appsv1.ControllerRevision{},
+		"ControllerRevisionList":           &appsv1.ControllerRevisionList{},
+		"DaemonSet":                        &appsv1.DaemonSet{},
+		"DaemonSetCondition":               &appsv1.DaemonSetCondition{},
+		"DaemonSetList":                    &appsv1.DaemonSetList{},
+		"DaemonSetSpec":                    &appsv1.DaemonSetSpec{},
+		"DaemonSetStatus":                  &appsv1.DaemonSetStatus{},
+		"DaemonSetUpdateStrategy":          &appsv1.DaemonSetUpdateStrategy{},
+		"Deployment":                       &appsv1.Deployment{},
+		"DeploymentCondition":              &appsv1.DeploymentCondition{},
+		"DeploymentList":                   &appsv1.DeploymentList{},
+		"DeploymentSpec":                   &appsv1.DeploymentSpec{},
+		"DeploymentStatus":                 &appsv1.DeploymentStatus{},
+		"DeploymentStrategy":               &appsv1.DeploymentStrategy{},
+		"ReplicaSet":                       &appsv1.ReplicaSet{},
+		"ReplicaSetCondition":              &appsv1.ReplicaSetCondition{},
+		"ReplicaSetList":                   &appsv1.ReplicaSetList{},
+		"ReplicaSetSpec":                   &appsv1.ReplicaSetSpec{},
+		"ReplicaSetStatus":                 &appsv1.ReplicaSetStatus{},
+		"RollingUpdateDaemonSet":           &appsv1.RollingUpdateDaemonSet{},
+		"RollingUpdateDeployment":          &appsv1.RollingUpdateDeployment{},
+		"RollingUpdateStatefulSetStrategy": &appsv1.RollingUpdateStatefulSetStrategy{},
+		"StatefulSet":                      &appsv1.StatefulSet{},
+		"StatefulSetCondition":             &appsv1.StatefulSetCondition{},
+		"StatefulSetList":                  &appsv1.StatefulSetList{},
+		"StatefulSetOrdinals":              &appsv1.StatefulSetOrdinals{},
+		"StatefulSetPersistentVolumeClaimRetentionPolicy": &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{},
+		"StatefulSetSpec":           &appsv1.StatefulSetSpec{},
+		"StatefulSetStatus":         &appsv1.StatefulSetStatus{},
+		"StatefulSetUpdateStrategy": &appsv1.StatefulSetUpdateStrategy{},
+	},
+	"k8s.io/api/authentication/v1": {
+		"BoundObjectReference":    &authenticationv1.BoundObjectReference{},
+		"SelfSubjectReview":       &authenticationv1.SelfSubjectReview{},
+		"SelfSubjectReviewStatus": &authenticationv1.SelfSubjectReviewStatus{},
+		"TokenRequest":            &authenticationv1.TokenRequest{},
+		"TokenRequestSpec":        &authenticationv1.TokenRequestSpec{},
+		"TokenRequestStatus":      &authenticationv1.TokenRequestStatus{},
+		"TokenReview":             &authenticationv1.TokenReview{},
+		"TokenReviewSpec":         &authenticationv1.TokenReviewSpec{},
+		"TokenReviewStatus":       &authenticationv1.TokenReviewStatus{},
+		"UserInfo":                &authenticationv1.UserInfo{},
+	},
+	"k8s.io/api/authorization/v1": {
+		"FieldSelectorAttributes":     &authorizationv1.FieldSelectorAttributes{},
+		"LabelSelectorAttributes":     &authorizationv1.LabelSelectorAttributes{},
+		"LocalSubjectAccessReview":    &authorizationv1.LocalSubjectAccessReview{},
+		"NonResourceAttributes":       &authorizationv1.NonResourceAttributes{},
+		"NonResourceRule":             &authorizationv1.NonResourceRule{},
+		"ResourceAttributes":          &authorizationv1.ResourceAttributes{},
+		"ResourceRule":                &authorizationv1.ResourceRule{},
+		"SelfSubjectAccessReview":     &authorizationv1.SelfSubjectAccessReview{},
+		"SelfSubjectAccessReviewSpec": &authorizationv1.SelfSubjectAccessReviewSpec{},
+		"SelfSubjectRulesReview":      &authorizationv1.SelfSubjectRulesReview{},
+		"SelfSubjectRulesReviewSpec":  &authorizationv1.SelfSubjectRulesReviewSpec{},
+		"SubjectAccessReview":         &authorizationv1.SubjectAccessReview{},
+		"SubjectAccessReviewSpec":     &authorizationv1.SubjectAccessReviewSpec{},
+		"SubjectAccessReviewStatus":   &authorizationv1.SubjectAccessReviewStatus{},
+		"SubjectRulesReviewStatus":    &authorizationv1.SubjectRulesReviewStatus{},
+	},
+	"k8s.io/api/autoscaling/v2": {
+		"ContainerResourceMetricSource":    &autoscalingv2.ContainerResourceMetricSource{},
+		"ContainerResourceMetricStatus":    &autoscalingv2.ContainerResourceMetricStatus{},
+		"CrossVersionObjectReference":      &autoscalingv2.CrossVersionObjectReference{},
+		"ExternalMetricSource":             &autoscalingv2.ExternalMetricSource{},
+		"ExternalMetricStatus":             &autoscalingv2.ExternalMetricStatus{},
+		"HPAScalingPolicy":                 &autoscalingv2.HPAScalingPolicy{},
+		"HPAScalingRules":                  &autoscalingv2.HPAScalingRules{},
+		"HorizontalPodAutoscaler":          &autoscalingv2.HorizontalPodAutoscaler{},
+		"HorizontalPodAutoscalerBehavior":  &autoscalingv2.HorizontalPodAutoscalerBehavior{},
+		"HorizontalPodAutoscalerCondition": &autoscalingv2.HorizontalPodAutoscalerCondition{},
+		"HorizontalPodAutoscalerList":      &autoscalingv2.HorizontalPodAutoscalerList{},
+		"HorizontalPodAutoscalerSpec":      &autoscalingv2.HorizontalPodAutoscalerSpec{},
+		"HorizontalPodAutoscalerStatus":    &autoscalingv2.HorizontalPodAutoscalerStatus{},
+		"MetricIdentifier":                 &autoscalingv2.MetricIdentifier{},
+		"MetricSpec":                       &autoscalingv2.MetricSpec{},
+		"MetricStatus":                     &autoscalingv2.MetricStatus{},
+		"MetricTarget":                     &autoscalingv2.MetricTarget{},
+		"MetricValueStatus":                &autoscalingv2.MetricValueStatus{},
+		"ObjectMetricSource":               &autoscalingv2.ObjectMetricSource{},
+		"ObjectMetricStatus":               &autoscalingv2.ObjectMetricStatus{},
+		"PodsMetricSource":                 &autoscalingv2.PodsMetricSource{},
+		"PodsMetricStatus":                 &autoscalingv2.PodsMetricStatus{},
+		"ResourceMetricSource":             &autoscalingv2.ResourceMetricSource{},
+		"ResourceMetricStatus":             &autoscalingv2.ResourceMetricStatus{},
+	},
+	"k8s.io/api/batch/v1": {
+		"CronJob":                                &batchv1.CronJob{},
+		"CronJobList":                            &batchv1.CronJobList{},
+		"CronJobSpec":                            &batchv1.CronJobSpec{},
+		"CronJobStatus":                          &batchv1.CronJobStatus{},
+		"Job":                                    &batchv1.Job{},
+		"JobCondition":                           &batchv1.JobCondition{},
+		"JobList":                                &batchv1.JobList{},
+		"JobSpec":                                &batchv1.JobSpec{},
+		"JobStatus":                              &batchv1.JobStatus{},
+		"JobTemplateSpec":                        &batchv1.JobTemplateSpec{},
+		"PodFailurePolicy":                       &batchv1.PodFailurePolicy{},
+		"PodFailurePolicyOnExitCodesRequirement": &batchv1.PodFailurePolicyOnExitCodesRequirement{},
+		"PodFailurePolicyOnPodConditionsPattern": &batchv1.PodFailurePolicyOnPodConditionsPattern{},
+		"PodFailurePolicyRule":                   &batchv1.PodFailurePolicyRule{},
+		"SuccessPolicy":                          &batchv1.SuccessPolicy{},
+		"SuccessPolicyRule":                      &batchv1.SuccessPolicyRule{},
+		"UncountedTerminatedPods":                &batchv1.UncountedTerminatedPods{},
+	},
+	"k8s.io/api/certificates/v1": {
+		"CertificateSigningRequest":          &certificatesv1.CertificateSigningRequest{},
+		"CertificateSigningRequestCondition": &certificatesv1.CertificateSigningRequestCondition{},
+		"CertificateSigningRequestList":      &certificatesv1.CertificateSigningRequestList{},
+		"CertificateSigningRequestSpec":      &certificatesv1.CertificateSigningRequestSpec{},
+		"CertificateSigningRequestStatus":    &certificatesv1.CertificateSigningRequestStatus{},
+	},
+	"k8s.io/api/coordination/v1": {
+		"Lease":     &coordinationv1.Lease{},
+		"LeaseList": &coordinationv1.LeaseList{},
+		"LeaseSpec": &coordinationv1.LeaseSpec{},
+	},
+	"k8s.io/api/core/v1": {
+		"AWSElasticBlockStoreVolumeSource":  &corev1.AWSElasticBlockStoreVolumeSource{},
+		"Affinity":                          &corev1.Affinity{},
+		"AppArmorProfile":                   &corev1.AppArmorProfile{},
+		"AttachedVolume":                    &corev1.AttachedVolume{},
+		"AvoidPods":                         &corev1.AvoidPods{},
+		"AzureDiskVolumeSource":             &corev1.AzureDiskVolumeSource{},
+		"AzureFilePersistentVolumeSource":   &corev1.AzureFilePersistentVolumeSource{},
+		"AzureFileVolumeSource":             &corev1.AzureFileVolumeSource{},
+		"Binding":                           &corev1.Binding{},
+		"CSIPersistentVolumeSource":         &corev1.CSIPersistentVolumeSource{},
+		"CSIVolumeSource":                   &corev1.CSIVolumeSource{},
+		"Capabilities":                      &corev1.Capabilities{},
+		"CephFSPersistentVolumeSource":      &corev1.CephFSPersistentVolumeSource{},
+		"CephFSVolumeSource":                &corev1.CephFSVolumeSource{},
+		"CinderPersistentVolumeSource":      &corev1.CinderPersistentVolumeSource{},
+		"CinderVolumeSource":                &corev1.CinderVolumeSource{},
+		"ClientIPConfig":                    &corev1.ClientIPConfig{},
+		"ClusterTrustBundleProjection":      &corev1.ClusterTrustBundleProjection{},
+		"ComponentCondition":                &corev1.ComponentCondition{},
+		"ComponentStatus":                   &corev1.ComponentStatus{},
+		"ComponentStatusList":               &corev1.ComponentStatusList{},
+		"ConfigMap":                         &corev1.ConfigMap{},
+		"ConfigMapEnvSource":                &corev1.ConfigMapEnvSource{},
+		"ConfigMapKeySelector":              &corev1.ConfigMapKeySelector{},
+		"ConfigMapList":                     &corev1.ConfigMapList{},
+		"ConfigMapNodeConfigSource":         &corev1.ConfigMapNodeConfigSource{},
+		"ConfigMapProjection":               &corev1.ConfigMapProjection{},
+		"ConfigMapVolumeSource":             &corev1.ConfigMapVolumeSource{},
+		"Container":                         &corev1.Container{},
+		"ContainerImage":                    &corev1.ContainerImage{},
+		"ContainerPort":                     &corev1.ContainerPort{},
+		"ContainerResizePolicy":             &corev1.ContainerResizePolicy{},
+		"ContainerState":                    &corev1.ContainerState{},
+		"ContainerStateRunning":             &corev1.ContainerStateRunning{},
+		"ContainerStateTerminated":          &corev1.ContainerStateTerminated{},
+		"ContainerStateWaiting":             &corev1.ContainerStateWaiting{},
+		"ContainerStatus":                   &corev1.ContainerStatus{},
+		"ContainerUser":                     &corev1.ContainerUser{},
+		"DaemonEndpoint":                    &corev1.DaemonEndpoint{},
+		"DownwardAPIProjection":             &corev1.DownwardAPIProjection{},
+		"DownwardAPIVolumeFile":             &corev1.DownwardAPIVolumeFile{},
+		"DownwardAPIVolumeSource":           &corev1.DownwardAPIVolumeSource{},
+		"EmptyDirVolumeSource":              &corev1.EmptyDirVolumeSource{},
+		"EndpointAddress":                   &corev1.EndpointAddress{},
+		"EndpointPort":                      &corev1.EndpointPort{},
+		"EndpointSubset":                    &corev1.EndpointSubset{},
+		"Endpoints":                         &corev1.Endpoints{},
+		"EndpointsList":                     &corev1.EndpointsList{},
+		"EnvFromSource":                     &corev1.EnvFromSource{},
+		"EnvVar":                            &corev1.EnvVar{},
+		"EnvVarSource":                      &corev1.EnvVarSource{},
+		"EphemeralContainer":                &corev1.EphemeralContainer{},
+		"EphemeralContainerCommon":          &corev1.EphemeralContainerCommon{},
+		"EphemeralVolumeSource":             &corev1.EphemeralVolumeSource{},
+		"Event":                             &corev1.Event{},
+		"EventList":                         &corev1.EventList{},
+		"EventSeries":                       &corev1.EventSeries{},
+		"EventSource":                       &corev1.EventSource{},
+		"ExecAction":                        &corev1.ExecAction{},
+		"FCVolumeSource":                    &corev1.FCVolumeSource{},
+		"FlexPersistentVolumeSource":        &corev1.FlexPersistentVolumeSource{},
+		"FlexVolumeSource":                  &corev1.FlexVolumeSource{},
+		"FlockerVolumeSource":               &corev1.FlockerVolumeSource{},
+		"GCEPersistentDiskVolumeSource":     &corev1.GCEPersistentDiskVolumeSource{},
+		"GRPCAction":                        &corev1.GRPCAction{},
+		"GitRepoVolumeSource":               &corev1.GitRepoVolumeSource{},
+		"GlusterfsPersistentVolumeSource":   &corev1.GlusterfsPersistentVolumeSource{},
+		"GlusterfsVolumeSource":             &corev1.GlusterfsVolumeSource{},
+		"HTTPGetAction":                     &corev1.HTTPGetAction{},
+		"HTTPHeader":                        &corev1.HTTPHeader{},
+		"HostAlias":                         &corev1.HostAlias{},
+		"HostIP":                            &corev1.HostIP{},
+		"HostPathVolumeSource":              &corev1.HostPathVolumeSource{},
+		"ISCSIPersistentVolumeSource":       &corev1.ISCSIPersistentVolumeSource{},
+		"ISCSIVolumeSource":                 &corev1.ISCSIVolumeSource{},
+		"ImageVolumeSource":                 &corev1.ImageVolumeSource{},
+		"KeyToPath":                         &corev1.KeyToPath{},
+		"Lifecycle":                         &corev1.Lifecycle{},
+		"LifecycleHandler":                  &corev1.LifecycleHandler{},
+		"LimitRange":                        &corev1.LimitRange{},
+		"LimitRangeItem":                    &corev1.LimitRangeItem{},
+		"LimitRangeList":                    &corev1.LimitRangeList{},
+		"LimitRangeSpec":                    &corev1.LimitRangeSpec{},
+		"LinuxContainerUser":                &corev1.LinuxContainerUser{},
+		"List":                              &corev1.List{},
+		"LoadBalancerIngress":               &corev1.LoadBalancerIngress{},
+		"LoadBalancerStatus":                &corev1.LoadBalancerStatus{},
+		"LocalObjectReference":              &corev1.LocalObjectReference{},
+		"LocalVolumeSource":                 &corev1.LocalVolumeSource{},
+		"ModifyVolumeStatus":                &corev1.ModifyVolumeStatus{},
+		"NFSVolumeSource":                   &corev1.NFSVolumeSource{},
+		"Namespace":                         &corev1.Namespace{},
+		"NamespaceCondition":                &corev1.NamespaceCondition{},
+		"NamespaceList":                     &corev1.NamespaceList{},
+		"NamespaceSpec":                     &corev1.NamespaceSpec{},
+		"NamespaceStatus":                   &corev1.NamespaceStatus{},
+		"Node":                              &corev1.Node{},
+		"NodeAddress":                       &corev1.NodeAddress{},
+		"NodeAffinity":                      &corev1.NodeAffinity{},
+		"NodeCondition":                     &corev1.NodeCondition{},
+		"NodeConfigSource":                  &corev1.NodeConfigSource{},
+		"NodeConfigStatus":                  &corev1.NodeConfigStatus{},
+		"NodeDaemonEndpoints":               &corev1.NodeDaemonEndpoints{},
+		"NodeFeatures":                      &corev1.NodeFeatures{},
+		"NodeList":                          &corev1.NodeList{},
+		"NodeProxyOptions":                  &corev1.NodeProxyOptions{},
+		"NodeRuntimeHandler":                &corev1.NodeRuntimeHandler{},
+		"NodeRuntimeHandlerFeatures":        &corev1.NodeRuntimeHandlerFeatures{},
+		"NodeSelector":                      &corev1.NodeSelector{},
+		"NodeSelectorRequirement":           &corev1.NodeSelectorRequirement{},
+		"NodeSelectorTerm":                  &corev1.NodeSelectorTerm{},
+		"NodeSpec":                          &corev1.NodeSpec{},
+		"NodeStatus":                        &corev1.NodeStatus{},
+		"NodeSystemInfo":                    &corev1.NodeSystemInfo{},
+		"ObjectFieldSelector":               &corev1.ObjectFieldSelector{},
+		"ObjectReference":                   &corev1.ObjectReference{},
+		"PersistentVolume":                  &corev1.PersistentVolume{},
+		"PersistentVolumeClaim":             &corev1.PersistentVolumeClaim{},
+		"PersistentVolumeClaimCondition":    &corev1.PersistentVolumeClaimCondition{},
+		"PersistentVolumeClaimList":         &corev1.PersistentVolumeClaimList{},
+		"PersistentVolumeClaimSpec":         &corev1.PersistentVolumeClaimSpec{},
+		"PersistentVolumeClaimStatus":       &corev1.PersistentVolumeClaimStatus{},
+		"PersistentVolumeClaimTemplate":     &corev1.PersistentVolumeClaimTemplate{},
+		"PersistentVolumeClaimVolumeSource": &corev1.PersistentVolumeClaimVolumeSource{},
+		"PersistentVolumeList":              &corev1.PersistentVolumeList{},
+		"PersistentVolumeSource":            &corev1.PersistentVolumeSource{},
+		"PersistentVolumeSpec":              &corev1.PersistentVolumeSpec{},
+		"PersistentVolumeStatus":            &corev1.PersistentVolumeStatus{},
+		"PhotonPersistentDiskVolumeSource":  &corev1.PhotonPersistentDiskVolumeSource{},
+		"Pod":                               &corev1.Pod{},
+		"PodAffinity":                       &corev1.PodAffinity{},
+		"PodAffinityTerm":                   &corev1.PodAffinityTerm{},
+		"PodAntiAffinity":                   &corev1.PodAntiAffinity{},
+		"PodAttachOptions":                  &corev1.PodAttachOptions{},
+		"PodCondition":                      &corev1.PodCondition{},
+		"PodDNSConfig":                      &corev1.PodDNSConfig{},
+		"PodDNSConfigOption":                &corev1.PodDNSConfigOption{},
+		"PodExecOptions":                    &corev1.PodExecOptions{},
+		"PodIP":                             &corev1.PodIP{},
+		"PodList":                           &corev1.PodList{},
+		"PodLogOptions":                     &corev1.PodLogOptions{},
+		"PodOS":                             &corev1.PodOS{},
+		"PodPortForwardOptions":             &corev1.PodPortForwardOptions{},
+		"PodProxyOptions":                   &corev1.PodProxyOptions{},
+		"PodReadinessGate":                  &corev1.PodReadinessGate{},
+		"PodResourceClaim":                  &corev1.PodResourceClaim{},
+		"PodResourceClaimStatus":            &corev1.PodResourceClaimStatus{},
+		"PodSchedulingGate":                 &corev1.PodSchedulingGate{},
+		"PodSecurityContext":                &corev1.PodSecurityContext{},
+		"PodSignature":                      &corev1.PodSignature{},
+		"PodSpec":                           &corev1.PodSpec{},
+		"PodStatus":                         &corev1.PodStatus{},
+		"PodStatusResult":                   &corev1.PodStatusResult{},
+		"PodTemplate":                       &corev1.PodTemplate{},
+		"PodTemplateList":                   &corev1.PodTemplateList{},
+		"PodTemplateSpec":                   &corev1.PodTemplateSpec{},
+		"PortStatus":                        &corev1.PortStatus{},
+		"PortworxVolumeSource":              &corev1.PortworxVolumeSource{},
+		"Preconditions":                     &corev1.Preconditions{},
+		"PreferAvoidPodsEntry":              &corev1.PreferAvoidPodsEntry{},
+		"PreferredSchedulingTerm":           &corev1.PreferredSchedulingTerm{},
+		"Probe":                             &corev1.Probe{},
+		"ProbeHandler":                      &corev1.ProbeHandler{},
+		"ProjectedVolumeSource":             &corev1.ProjectedVolumeSource{},
+		"QuobyteVolumeSource":               &corev1.QuobyteVolumeSource{},
+		"RBDPersistentVolumeSource":         &corev1.RBDPersistentVolumeSource{},
+		"RBDVolumeSource":                   &corev1.RBDVolumeSource{},
+		"RangeAllocation":                   &corev1.RangeAllocation{},
+		"ReplicationController":             &corev1.ReplicationController{},
+		"ReplicationControllerCondition":    &corev1.ReplicationControllerCondition{},
+		"ReplicationControllerList":         &corev1.ReplicationControllerList{},
+		"ReplicationControllerSpec":         &corev1.ReplicationControllerSpec{},
+		"ReplicationControllerStatus":       &corev1.ReplicationControllerStatus{},
+		"ResourceClaim":                     &corev1.ResourceClaim{},
+		"ResourceFieldSelector":             &corev1.ResourceFieldSelector{},
+		"ResourceHealth":                    &corev1.ResourceHealth{},
+		"ResourceQuota":                     &corev1.ResourceQuota{},
+		"ResourceQuotaList":                 &corev1.ResourceQuotaList{},
+		"ResourceQuotaSpec":                 &corev1.ResourceQuotaSpec{},
+		"ResourceQuotaStatus":               &corev1.ResourceQuotaStatus{},
+		"ResourceRequirements":              &corev1.ResourceRequirements{},
+		"ResourceStatus":                    &corev1.ResourceStatus{},
+		"SELinuxOptions":                    &corev1.SELinuxOptions{},
+		"ScaleIOPersistentVolumeSource":     &corev1.ScaleIOPersistentVolumeSource{},
+		"ScaleIOVolumeSource":               &corev1.ScaleIOVolumeSource{},
+		"ScopeSelector":                     &corev1.ScopeSelector{},
+		"ScopedResourceSelectorRequirement": &corev1.ScopedResourceSelectorRequirement{},
+		"SeccompProfile":                    &corev1.SeccompProfile{},
+		"Secret":                            &corev1.Secret{},
+		"SecretEnvSource":                   &corev1.SecretEnvSource{},
+		"SecretKeySelector":                 &corev1.SecretKeySelector{},
+		"SecretList":                        &corev1.SecretList{},
+		"SecretProjection":                  &corev1.SecretProjection{},
+		"SecretReference":                   &corev1.SecretReference{},
+		"SecretVolumeSource":                &corev1.SecretVolumeSource{},
+		"SecurityContext":                   &corev1.SecurityContext{},
+		"SerializedReference":               &corev1.SerializedReference{},
+		"Service":                           &corev1.Service{},
+		"ServiceAccount":                    &corev1.ServiceAccount{},
+		"ServiceAccountList":                &corev1.ServiceAccountList{},
+		"ServiceAccountTokenProjection":     &corev1.ServiceAccountTokenProjection{},
+		"ServiceList":                       &corev1.ServiceList{},
+		"ServicePort":                       &corev1.ServicePort{},
+		"ServiceProxyOptions":               &corev1.ServiceProxyOptions{},
+		"ServiceSpec":                       &corev1.ServiceSpec{},
+		"ServiceStatus":                     &corev1.ServiceStatus{},
+		"SessionAffinityConfig":             &corev1.SessionAffinityConfig{},
+		"SleepAction":                       &corev1.SleepAction{},
+		"StorageOSPersistentVolumeSource":   &corev1.StorageOSPersistentVolumeSource{},
+		"StorageOSVolumeSource":             &corev1.StorageOSVolumeSource{},
+		"Sysctl":                            &corev1.Sysctl{},
+		"TCPSocketAction":                   &corev1.TCPSocketAction{},
+		"Taint":                             &corev1.Taint{},
+		"Toleration":                        &corev1.Toleration{},
+		"TopologySelectorLabelRequirement":  &corev1.TopologySelectorLabelRequirement{},
+		"TopologySelectorTerm":              &corev1.TopologySelectorTerm{},
+		"TopologySpreadConstraint":          &corev1.TopologySpreadConstraint{},
+		"TypedLocalObjectReference":         &corev1.TypedLocalObjectReference{},
+		"TypedObjectReference":              &corev1.TypedObjectReference{},
+		"Volume":                            &corev1.Volume{},
+		"VolumeDevice":                      &corev1.VolumeDevice{},
+		"VolumeMount":                       &corev1.VolumeMount{},
+		"VolumeMountStatus":                 &corev1.VolumeMountStatus{},
+		"VolumeNodeAffinity":                &corev1.VolumeNodeAffinity{},
+		"VolumeProjection":                  &corev1.VolumeProjection{},
+		"VolumeResourceRequirements":        &corev1.VolumeResourceRequirements{},
+		"VolumeSource":                      &corev1.VolumeSource{},
+		"VsphereVirtualDiskVolumeSource":    &corev1.VsphereVirtualDiskVolumeSource{},
+		"WeightedPodAffinityTerm":           &corev1.WeightedPodAffinityTerm{},
+		"WindowsSecurityContextOptions":     &corev1.WindowsSecurityContextOptions{},
+	},
+	"k8s.io/api/discovery/v1": {
+		"Endpoint":           &discoveryv1.Endpoint{},
+		"EndpointConditions": &discoveryv1.EndpointConditions{},
+		"EndpointHints":      &discoveryv1.EndpointHints{},
+		"EndpointPort":       &discoveryv1.EndpointPort{},
+		"EndpointSlice":      &discoveryv1.EndpointSlice{},
+		"EndpointSliceList":  &discoveryv1.EndpointSliceList{},
+		"ForZone":            &discoveryv1.ForZone{},
+	},
+	"k8s.io/api/events/v1": {
+		"Event":       &eventsv1.Event{},
+		"EventList":   &eventsv1.EventList{},
+		"EventSeries": &eventsv1.EventSeries{},
+	},
+	"k8s.io/api/extensions/v1beta1": {
+		"DaemonSet":                  &extensionsv1beta1.DaemonSet{},
+		"DaemonSetCondition":         &extensionsv1beta1.DaemonSetCondition{},
+		"DaemonSetList":              &extensionsv1beta1.DaemonSetList{},
+		"DaemonSetSpec":              &extensionsv1beta1.DaemonSetSpec{},
+		"DaemonSetStatus":            &extensionsv1beta1.DaemonSetStatus{},
+		"DaemonSetUpdateStrategy":    &extensionsv1beta1.DaemonSetUpdateStrategy{},
+		"Deployment":                 &extensionsv1beta1.Deployment{},
+		"DeploymentCondition":        &extensionsv1beta1.DeploymentCondition{},
+		"DeploymentList":             &extensionsv1beta1.DeploymentList{},
+		"DeploymentRollback":         &extensionsv1beta1.DeploymentRollback{},
+		"DeploymentSpec":             &extensionsv1beta1.DeploymentSpec{},
+		"DeploymentStatus":           &extensionsv1beta1.DeploymentStatus{},
+		"DeploymentStrategy":         &extensionsv1beta1.DeploymentStrategy{},
+		"HTTPIngressPath":            &extensionsv1beta1.HTTPIngressPath{},
+		"HTTPIngressRuleValue":       &extensionsv1beta1.HTTPIngressRuleValue{},
+		"IPBlock":                    &extensionsv1beta1.IPBlock{},
+		"Ingress":                    &extensionsv1beta1.Ingress{},
+		"IngressBackend":             &extensionsv1beta1.IngressBackend{},
+		"IngressList":                &extensionsv1beta1.IngressList{},
+		"IngressLoadBalancerIngress": &extensionsv1beta1.IngressLoadBalancerIngress{},
+		"IngressLoadBalancerStatus":  &extensionsv1beta1.IngressLoadBalancerStatus{},
+		"IngressPortStatus":          &extensionsv1beta1.IngressPortStatus{},
+		"IngressRule":                &extensionsv1beta1.IngressRule{},
+		"IngressRuleValue":           &extensionsv1beta1.IngressRuleValue{},
+		"IngressSpec":                &extensionsv1beta1.IngressSpec{},
+		"IngressStatus":              &extensionsv1beta1.IngressStatus{},
+		"IngressTLS":                 &extensionsv1beta1.IngressTLS{},
+		"NetworkPolicy":              &extensionsv1beta1.NetworkPolicy{},
+		"NetworkPolicyEgressRule":    &extensionsv1beta1.NetworkPolicyEgressRule{},
+		"NetworkPolicyIngressRule":   &extensionsv1beta1.NetworkPolicyIngressRule{},
+		"NetworkPolicyList":          &extensionsv1beta1.NetworkPolicyList{},
+		"NetworkPolicyPeer":          &extensionsv1beta1.NetworkPolicyPeer{},
+		"NetworkPolicyPort":          &extensionsv1beta1.NetworkPolicyPort{},
+		"NetworkPolicySpec":          &extensionsv1beta1.NetworkPolicySpec{},
+		"ReplicaSet":                 &extensionsv1beta1.ReplicaSet{},
+		"ReplicaSetCondition":        &extensionsv1beta1.ReplicaSetCondition{},
+		"ReplicaSetList":             &extensionsv1beta1.ReplicaSetList{},
+		"ReplicaSetSpec":             &extensionsv1beta1.ReplicaSetSpec{},
+		"ReplicaSetStatus":           &extensionsv1beta1.ReplicaSetStatus{},
+		"RollbackConfig":             &extensionsv1beta1.RollbackConfig{},
+		"RollingUpdateDaemonSet":     &extensionsv1beta1.RollingUpdateDaemonSet{},
+		"RollingUpdateDeployment":    &extensionsv1beta1.RollingUpdateDeployment{},
+		"Scale":                      &extensionsv1beta1.Scale{},
+		"ScaleSpec":                  &extensionsv1beta1.ScaleSpec{},
+		"ScaleStatus":                &extensionsv1beta1.ScaleStatus{},
+	},
+	"k8s.io/api/flowcontrol/v1": {
+		"ExemptPriorityLevelConfiguration":    &flowcontrolv1.ExemptPriorityLevelConfiguration{},
+		"FlowDistinguisherMethod":             &flowcontrolv1.FlowDistinguisherMethod{},
+		"FlowSchema":                          &flowcontrolv1.FlowSchema{},
+		"FlowSchemaCondition":                 &flowcontrolv1.FlowSchemaCondition{},
+		"FlowSchemaList":                      &flowcontrolv1.FlowSchemaList{},
+		"FlowSchemaSpec":                      &flowcontrolv1.FlowSchemaSpec{},
+		"FlowSchemaStatus":                    &flowcontrolv1.FlowSchemaStatus{},
+		"GroupSubject":                        &flowcontrolv1.GroupSubject{},
+		"LimitResponse":                       &flowcontrolv1.LimitResponse{},
+		"LimitedPriorityLevelConfiguration":   &flowcontrolv1.LimitedPriorityLevelConfiguration{},
+		"NonResourcePolicyRule":               &flowcontrolv1.NonResourcePolicyRule{},
+		"PolicyRulesWithSubjects":             &flowcontrolv1.PolicyRulesWithSubjects{},
+		"PriorityLevelConfiguration":          &flowcontrolv1.PriorityLevelConfiguration{},
+		"PriorityLevelConfigurationCondition": &flowcontrolv1.PriorityLevelConfigurationCondition{},
+		"PriorityLevelConfigurationList":      &flowcontrolv1.PriorityLevelConfigurationList{},
+		"PriorityLevelConfigurationReference": &flowcontrolv1.PriorityLevelConfigurationReference{},
+		"PriorityLevelConfigurationSpec":      &flowcontrolv1.PriorityLevelConfigurationSpec{},
+		"PriorityLevelConfigurationStatus":    &flowcontrolv1.PriorityLevelConfigurationStatus{},
+		"QueuingConfiguration":                &flowcontrolv1.QueuingConfiguration{},
+		"ResourcePolicyRule":                  &flowcontrolv1.ResourcePolicyRule{},
+		"ServiceAccountSubject":               &flowcontrolv1.ServiceAccountSubject{},
+		"Subject":                             &flowcontrolv1.Subject{},
+		"UserSubject":                         &flowcontrolv1.UserSubject{},
+	},
+	"k8s.io/api/apiserverinternal/v1alpha1": {
+		"ServerStorageVersion":    &internalv1alpha1.ServerStorageVersion{},
+		"StorageVersion":          &internalv1alpha1.StorageVersion{},
+		"StorageVersionCondition": &internalv1alpha1.StorageVersionCondition{},
+		"StorageVersionList":      &internalv1alpha1.StorageVersionList{},
+		"StorageVersionSpec":      &internalv1alpha1.StorageVersionSpec{},
+		"StorageVersionStatus":    &internalv1alpha1.StorageVersionStatus{},
+	},
+	"k8s.io/api/networking/v1": {
+		"HTTPIngressPath":                 &networkingv1.HTTPIngressPath{},
+		"HTTPIngressRuleValue":            &networkingv1.HTTPIngressRuleValue{},
+		"IPBlock":                         &networkingv1.IPBlock{},
+		"Ingress":                         &networkingv1.Ingress{},
+		"IngressBackend":                  &networkingv1.IngressBackend{},
+		"IngressClass":                    &networkingv1.IngressClass{},
+		"IngressClassList":                &networkingv1.IngressClassList{},
+		"IngressClassParametersReference": &networkingv1.IngressClassParametersReference{},
+		"IngressClassSpec":                &networkingv1.IngressClassSpec{},
+		"IngressList":                     &networkingv1.IngressList{},
+		"IngressLoadBalancerIngress":      &networkingv1.IngressLoadBalancerIngress{},
+		"IngressLoadBalancerStatus":       &networkingv1.IngressLoadBalancerStatus{},
+		"IngressPortStatus":               &networkingv1.IngressPortStatus{},
+		"IngressRule":                     &networkingv1.IngressRule{},
+		"IngressRuleValue":                &networkingv1.IngressRuleValue{},
+		"IngressServiceBackend":           &networkingv1.IngressServiceBackend{},
+		"IngressSpec":                     &networkingv1.IngressSpec{},
+		"IngressStatus":                   &networkingv1.IngressStatus{},
+		"IngressTLS":                      &networkingv1.IngressTLS{},
+		"NetworkPolicy":                   &networkingv1.NetworkPolicy{},
+		"NetworkPolicyEgressRule":         &networkingv1.NetworkPolicyEgressRule{},
+		"NetworkPolicyIngressRule":        &networkingv1.NetworkPolicyIngressRule{},
+		"NetworkPolicyList":               &networkingv1.NetworkPolicyList{},
+		"NetworkPolicyPeer":               &networkingv1.NetworkPolicyPeer{},
+		"NetworkPolicyPort":               &networkingv1.NetworkPolicyPort{},
+		"NetworkPolicySpec":               &networkingv1.NetworkPolicySpec{},
+		"ServiceBackendPort":              &networkingv1.ServiceBackendPort{},
+	},
+	"k8s.io/api/node/v1": {
+		"Overhead":         &nodev1.Overhead{},
+		"RuntimeClass":     &nodev1.RuntimeClass{},
+		"RuntimeClassList": &nodev1.RuntimeClassList{},
+		"Scheduling":       &nodev1.Scheduling{},
+	},
+	"k8s.io/api/policy/v1": {
+		"Eviction":                  &policyv1.Eviction{},
+		"PodDisruptionBudget":       &policyv1.PodDisruptionBudget{},
+		"PodDisruptionBudgetList":   &policyv1.PodDisruptionBudgetList{},
+		"PodDisruptionBudgetSpec":   &policyv1.PodDisruptionBudgetSpec{},
+		"PodDisruptionBudgetStatus": &policyv1.PodDisruptionBudgetStatus{},
+	},
+	"k8s.io/api/rbac/v1": {
+		"AggregationRule":        &rbacv1.AggregationRule{},
+		"ClusterRole":            &rbacv1.ClusterRole{},
+		"ClusterRoleBinding":     &rbacv1.ClusterRoleBinding{},
+		"ClusterRoleBindingList": &rbacv1.ClusterRoleBindingList{},
+		"ClusterRoleList":        &rbacv1.ClusterRoleList{},
+		"PolicyRule":             &rbacv1.PolicyRule{},
+		"Role":                   &rbacv1.Role{},
+		"RoleBinding":            &rbacv1.RoleBinding{},
+		"RoleBindingList":        &rbacv1.RoleBindingList{},
+		"RoleList":               &rbacv1.RoleList{},
+		"RoleRef":                &rbacv1.RoleRef{},
+		"Subject":                &rbacv1.Subject{},
+	},
+	"k8s.io/api/resource/v1beta1": {
+		"AllocatedDeviceStatus":          &resourcev1beta1.AllocatedDeviceStatus{},
+		"AllocationResult":               &resourcev1beta1.AllocationResult{},
+		"BasicDevice":                    &resourcev1beta1.BasicDevice{},
+		"CELDeviceSelector":              &resourcev1beta1.CELDeviceSelector{},
+		"Device":                         &resourcev1beta1.Device{},
+		"DeviceAllocationConfiguration":  &resourcev1beta1.DeviceAllocationConfiguration{},
+		"DeviceAllocationResult":         &resourcev1beta1.DeviceAllocationResult{},
+		"DeviceAttribute":                &resourcev1beta1.DeviceAttribute{},
+		"DeviceCapacity":                 &resourcev1beta1.DeviceCapacity{},
+		"DeviceClaim":                    &resourcev1beta1.DeviceClaim{},
+		"DeviceClaimConfiguration":       &resourcev1beta1.DeviceClaimConfiguration{},
+		"DeviceClass":                    &resourcev1beta1.DeviceClass{},
+		"DeviceClassConfiguration":       &resourcev1beta1.DeviceClassConfiguration{},
+		"DeviceClassList":                &resourcev1beta1.DeviceClassList{},
+		"DeviceClassSpec":                &resourcev1beta1.DeviceClassSpec{},
+		"DeviceConfiguration":            &resourcev1beta1.DeviceConfiguration{},
+		"DeviceConstraint":               &resourcev1beta1.DeviceConstraint{},
+		"DeviceRequest":                  &resourcev1beta1.DeviceRequest{},
+		"DeviceRequestAllocationResult":  &resourcev1beta1.DeviceRequestAllocationResult{},
+		"DeviceSelector":                 &resourcev1beta1.DeviceSelector{},
+		"NetworkDeviceData":              &resourcev1beta1.NetworkDeviceData{},
+		"OpaqueDeviceConfiguration":      &resourcev1beta1.OpaqueDeviceConfiguration{},
+		"ResourceClaim":                  &resourcev1beta1.ResourceClaim{},
+		"ResourceClaimConsumerReference": &resourcev1beta1.ResourceClaimConsumerReference{},
+		"ResourceClaimList":              &resourcev1beta1.ResourceClaimList{},
+		"ResourceClaimSpec":              &resourcev1beta1.ResourceClaimSpec{},
+		"ResourceClaimStatus":            &resourcev1beta1.ResourceClaimStatus{},
+		"ResourceClaimTemplate":          &resourcev1beta1.ResourceClaimTemplate{},
+		"ResourceClaimTemplateList":      &resourcev1beta1.ResourceClaimTemplateList{},
+		"ResourceClaimTemplateSpec":      &resourcev1beta1.ResourceClaimTemplateSpec{},
+		"ResourcePool":                   &resourcev1beta1.ResourcePool{},
+		"ResourceSlice":                  &resourcev1beta1.ResourceSlice{},
+		"ResourceSliceList":              &resourcev1beta1.ResourceSliceList{},
+		"ResourceSliceSpec":              &resourcev1beta1.ResourceSliceSpec{},
+	},
+	"k8s.io/api/scheduling/v1": {
+		"PriorityClass":     &schedulingv1.PriorityClass{},
+		"PriorityClassList": &schedulingv1.PriorityClassList{},
+	},
+	"github.com/veith4f/scropt/api/v1": {
+		"ArgSource":             &scriptsv1.ArgSource{},
+		"ClusterLuaModule":      &scriptsv1.ClusterLuaModule{},
+		"ClusterLuaModuleList":  &scriptsv1.ClusterLuaModuleList{},
+		"ClusterLuaScript":      &scriptsv1.ClusterLuaScript{},
+		"ClusterLuaScriptList":  &scriptsv1.ClusterLuaScriptList{},
+		"ClusterMoonScript":     &scriptsv1.ClusterMoonScript{},
+		"ClusterMoonScriptList": &scriptsv1.ClusterMoonScriptList{},
+		"LuaModule":             &scriptsv1.LuaModule{},
+		"LuaModuleList":         &scriptsv1.LuaModuleList{},
+		"LuaModuleSpec":         &scriptsv1.LuaModuleSpec{},
+		"LuaScript":             &scriptsv1.LuaScript{},
+		"LuaScriptList":         &scriptsv1.LuaScriptList{},
+		"LuaScriptSpec":         &scriptsv1.LuaScriptSpec{},
+		"LuaScriptStatus":       &scriptsv1.LuaScriptStatus{},
+		"MoonScript":            &scriptsv1.MoonScript{},
+		"MoonScriptList":        &scriptsv1.MoonScriptList{},
+		"MoonScriptSpec":        &scriptsv1.MoonScriptSpec{},
+		"MoonScriptStatus":      &scriptsv1.MoonScriptStatus{},
+		"Mutation":              &scriptsv1.Mutation{},
+		"ResourceLimits":        &scriptsv1.ResourceLimits{},
+		"ResultConfigMapSpec":   &scriptsv1.ResultConfigMapSpec{},
+		"RetryPolicy":           &scriptsv1.RetryPolicy{},
+		"ScheduleSpec":          &scriptsv1.ScheduleSpec{},
+		"ScriptArg":             &scriptsv1.ScriptArg{},
+		"ScriptError":           &scriptsv1.ScriptError{},
+		"ScriptReference":       &scriptsv1.ScriptReference{},
+		"ScriptRun":             &scriptsv1.ScriptRun{},
+		"ScriptRunList":         &scriptsv1.ScriptRunList{},
+		"ScriptRunSpec":         &scriptsv1.ScriptRunSpec{},
+		"ScriptRunStatus":       &scriptsv1.ScriptRunStatus{},
+		"ScriptSource":          &scriptsv1.ScriptSource{},
+		"ScriptSpec":            &scriptsv1.ScriptSpec{},
+		"ScriptStatus":          &scriptsv1.ScriptStatus{},
+		"TriggerSpec":           &scriptsv1.TriggerSpec{},
+	},
+	"k8s.io/api/storage/v1": {
+		"CSIDriver":              &storagev1.CSIDriver{},
+		"CSIDriverList":          &storagev1.CSIDriverList{},
+		"CSIDriverSpec":          &storagev1.CSIDriverSpec{},
+		"CSINode":                &storagev1.CSINode{},
+		"CSINodeDriver":          &storagev1.CSINodeDriver{},
+		"CSINodeList":            &storagev1.CSINodeList{},
+		"CSINodeSpec":            &storagev1.CSINodeSpec{},
+		"CSIStorageCapacity":     &storagev1.CSIStorageCapacity{},
+		"CSIStorageCapacityList": &storagev1.CSIStorageCapacityList{},
+		"StorageClass":           &storagev1.StorageClass{},
+		"StorageClassList":       &storagev1.StorageClassList{},
+		"TokenRequest":           &storagev1.TokenRequest{},
+		"VolumeAttachment":       &storagev1.VolumeAttachment{},
+		"VolumeAttachmentList":   &storagev1.VolumeAttachmentList{},
+		"VolumeAttachmentSource": &storagev1.VolumeAttachmentSource{},
+		"VolumeAttachmentSpec":   &storagev1.VolumeAttachmentSpec{},
+		"VolumeAttachmentStatus": &storagev1.VolumeAttachmentStatus{},
+		"VolumeError":            &storagev1.VolumeError{},
+		"VolumeNodeResources":    &storagev1.VolumeNodeResources{},
+	},
+	"k8s.io/api/storagemigration/v1alpha1": {
+		"GroupVersionResource":          &storagemigrationv1alpha1.GroupVersionResource{},
+		"MigrationCondition":            &storagemigrationv1alpha1.MigrationCondition{},
+		"StorageVersionMigration":       &storagemigrationv1alpha1.StorageVersionMigration{},
+		"StorageVersionMigrationList":   &storagemigrationv1alpha1.StorageVersionMigrationList{},
+		"StorageVersionMigrationSpec":   &storagemigrationv1alpha1.StorageVersionMigrationSpec{},
+		"StorageVersionMigrationStatus": &storagemigrationv1alpha1.StorageVersionMigrationStatus{},
+	},
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+	lua "github.com/veith4f/scropt/internal/lua"
+)
+
+// SetupMoonScriptWebhookWithManager registers the webhook for MoonScript in the manager.
+func SetupMoonScriptWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&scriptsv1.MoonScript{}).
+		WithValidator(&scriptValidator{kind: scriptsv1.GroupVersion.WithKind("MoonScript").GroupKind(), compile: lua.CompileMoonscript}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-scripts-scropt-io-v1-moonscript,mutating=false,failurePolicy=fail,sideEffects=None,groups=scripts.scropt.io,resources=moonscripts,verbs=create;update,versions=v1,name=vmoonscript-v1.kb.io,admissionReviewVersions=v1
@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+	lua "github.com/veith4f/scropt/internal/lua"
+)
+
+// scriptlog is for logging in this package.
+var scriptlog = logf.Log.WithName("script-resource")
+
+// scriptGlobals are the globals defined for scripts by the reconcilers in addition to those of lua.Exec.
+var scriptGlobals = []string{"args", "event"}
+
+// scriptObject is implemented by all script kinds.
+type scriptObject interface {
+	client.Object
+	GetScriptSpec() *scriptsv1.ScriptSpec
+}
+
+// scriptValidator rejects scripts of one kind whose code does not compile and warns
+// about globals read by the code that are not defined when it is executed.
+// Code loaded from ConfigMaps and Secrets is not validated.
+type scriptValidator struct {
+	kind schema.GroupKind
+	// compile translates code to Lua, it is nil for Lua scripts
+	compile func(code string) (string, error)
+}
+
+var _ webhook.CustomValidator = &scriptValidator{}
+
+// NewScriptValidator returns the validator of scripts of kind whose code is translated
+// to Lua by compile. compile is nil for Lua scripts.
+func NewScriptValidator(kind schema.GroupKind, compile func(code string) (string, error)) webhook.CustomValidator {
+	return &scriptValidator{kind: kind, compile: compile}
+}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *scriptValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(obj)
+}
+
+// ValidateUpdate implements webhook.CustomValidator. Updates that leave the code
+// unchanged are admitted, so that scripts stored before the webhook was installed
+// can still be annotated and released by their finalizer.
+func (v *scriptValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldScript, ok := oldObj.(scriptObject)
+	newScript, newOk := newObj.(scriptObject)
+	if ok && newOk && slices.Equal(code(oldScript), code(newScript)) {
+		return nil, nil
+	}
+	return v.validate(newObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *scriptValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *scriptValidator) validate(obj runtime.Object) (admission.Warnings, error) {
+	script, ok := obj.(scriptObject)
+	if !ok {
+		return nil, fmt.Errorf("expected a %s object but got %T", v.kind.Kind, obj)
+	}
+	scriptlog.Info("Validating", "kind", v.kind.Kind, "name", script.GetName())
+
+	spec := script.GetScriptSpec()
+	var warnings admission.Warnings
+	var errs field.ErrorList
+	check := func(path *field.Path, code string) {
+		if code == "" {
+			return
+		}
+		w, err := v.check(path, code)
+		warnings = append(warnings, w...)
+		if err != nil {
+			errs = append(errs, field.Invalid(path, field.OmitValueType{}, err.Error()))
+		}
+	}
+	check(field.NewPath("spec", "code"), spec.Code)
+	if spec.Source != nil {
+		check(field.NewPath("spec", "source", "inline"), spec.Source.Inline)
+	}
+	check(field.NewPath("spec", "onDelete"), spec.OnDelete)
+
+	if len(errs) > 0 {
+		return warnings, apierrors.NewInvalid(v.kind, script.GetName(), errs)
+	}
+	return warnings, nil
+}
+
+// check compiles code and returns warnings about undefined globals.
+func (v *scriptValidator) check(path *field.Path, code string) (admission.Warnings, error) {
+	luaCode := code
+	if v.compile != nil {
+		var err error
+		if luaCode, err = v.compile(code); err != nil {
+			return nil, describe(err)
+		}
+	}
+
+	undefined, err := lua.UndefinedGlobals(luaCode, scriptGlobals...)
+	if err != nil {
+		return nil, describe(err)
+	}
+	var warnings admission.Warnings
+	for _, global := range undefined {
+		if v.compile != nil {
+			// lines refer to the compiled code
+			warnings = append(warnings, fmt.Sprintf("%s: global %q is not defined", path, global.Name))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s: line %d: global %q is not defined", path, global.Line, global.Name))
+		}
+	}
+	return warnings, nil
+}
+
+// code returns the inline code of a script.
+func code(script scriptObject) []string {
+	spec := script.GetScriptSpec()
+	code := []string{spec.Code, spec.OnDelete}
+	if spec.Source != nil {
+		code = append(code, spec.Source.Inline)
+	}
+	return code
+}
+
+// describe formats compile errors with their position.
+func describe(err error) error {
+	var scriptErr *lua.ScriptError
+	if !errors.As(err, &scriptErr) {
+		return err
+	}
+	apiErr := &scriptsv1.ScriptError{Message: scriptErr.Message, Line: int32(scriptErr.Line), Column: int32(scriptErr.Column)}
+	return errors.New(apiErr.String())
+}
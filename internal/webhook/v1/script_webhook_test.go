@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scriptsv1 "github.com/veith4f/scropt/api/v1"
+	lua "github.com/veith4f/scropt/internal/lua"
+)
+
+var _ = Describe("Script webhook", func() {
+	ctx := context.Background()
+	validator := &scriptValidator{kind: scriptsv1.GroupVersion.WithKind("LuaScript").GroupKind()}
+
+	newScript := func(code string) *scriptsv1.LuaScript {
+		script := &scriptsv1.LuaScript{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}
+		script.Spec.Code = code
+		return script
+	}
+
+	It("should admit valid code", func() {
+		warnings, err := validator.ValidateCreate(ctx, newScript(`log("%s", args.name)`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("should reject syntax errors with their position", func() {
+		_, err := validator.ValidateCreate(ctx, newScript("local x = 1\nx = = 2"))
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.code"))
+		Expect(err.Error()).To(ContainSubstring("line 2, column 5"))
+
+		details := err.(apierrors.APIStatus).Status().Details
+		Expect(details.Group).To(Equal(scriptsv1.GroupVersion.Group))
+		Expect(details.Kind).To(Equal("LuaScript"))
+	})
+
+	It("should validate onDelete code", func() {
+		script := newScript(`log("ok")`)
+		script.Spec.OnDelete = `if then end`
+		_, err := validator.ValidateCreate(ctx, script)
+		Expect(err).To(MatchError(ContainSubstring("spec.onDelete")))
+	})
+
+	It("should warn about undefined globals", func() {
+		warnings, err := validator.ValidateCreate(ctx, newScript("local x = 1\nprint(x, y)\nfunction f() return z end\nz = 1"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(`spec.code: line 2: global "y" is not defined`))
+	})
+
+	It("should admit updates that leave invalid code unchanged", func() {
+		old := newScript(`x = = 1`)
+		script := old.DeepCopy()
+		script.Annotations = map[string]string{scriptsv1.SkipOnDeleteAnnotation: "true"}
+		_, err := validator.ValidateUpdate(ctx, old, script)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should report compile errors of other languages", func() {
+		moon := &scriptValidator{kind: scriptsv1.GroupVersion.WithKind("MoonScript").GroupKind(), compile: func(string) (string, error) {
+			return "", &lua.ScriptError{Message: "failed to parse", Line: 3}
+		}}
+		_, err := moon.ValidateCreate(ctx, &scriptsv1.MoonScript{Spec: scriptsv1.MoonScriptSpec{
+			ScriptSpec: scriptsv1.ScriptSpec{Code: "x ="},
+		}})
+		Expect(err).To(MatchError(ContainSubstring("line 3: failed to parse")))
+	})
+})
@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	scriptsv2 "github.com/veith4f/scropt/api/v2"
+	lua "github.com/veith4f/scropt/internal/lua"
+	webhookv1 "github.com/veith4f/scropt/internal/webhook/v1"
+)
+
+// SetupScriptWebhookWithManager registers the webhook for Script in the manager.
+func SetupScriptWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&scriptsv2.Script{}).
+		WithValidator(newScriptValidator()).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-scripts-scropt-io-v2-script,mutating=false,failurePolicy=fail,sideEffects=None,groups=scripts.scropt.io,resources=scripts,verbs=create;update,versions=v2,name=vscript-v2.kb.io,admissionReviewVersions=v1
+
+// scriptValidator validates Scripts like the v1 script kinds of their language.
+type scriptValidator struct {
+	languages map[scriptsv2.Language]webhook.CustomValidator
+}
+
+var _ webhook.CustomValidator = &scriptValidator{}
+
+func newScriptValidator() *scriptValidator {
+	kind := scriptsv2.GroupVersion.WithKind("Script").GroupKind()
+	return &scriptValidator{languages: map[scriptsv2.Language]webhook.CustomValidator{
+		scriptsv2.LanguageLua:        webhookv1.NewScriptValidator(kind, nil),
+		scriptsv2.LanguageMoonScript: webhookv1.NewScriptValidator(kind, lua.CompileMoonscript),
+	}}
+}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *scriptValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	validator, err := v.validatorFor(obj)
+	if validator == nil {
+		return nil, err
+	}
+	return validator.ValidateCreate(ctx, obj)
+}
+
+// ValidateUpdate implements webhook.CustomValidator. Code is validated again
+// if the language changed, even if the code itself did not.
+func (v *scriptValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	validator, err := v.validatorFor(newObj)
+	if validator == nil {
+		return nil, err
+	}
+	oldScript, ok := oldObj.(*scriptsv2.Script)
+	if !ok || oldScript.Spec.Language != newObj.(*scriptsv2.Script).Spec.Language {
+		return validator.ValidateCreate(ctx, newObj)
+	}
+	return validator.ValidateUpdate(ctx, oldObj, newObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *scriptValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validatorFor returns the validator of the language of a Script. It returns no validator
+// for unknown languages, which are rejected by the schema of the CRD.
+func (v *scriptValidator) validatorFor(obj runtime.Object) (webhook.CustomValidator, error) {
+	script, ok := obj.(*scriptsv2.Script)
+	if !ok {
+		return nil, fmt.Errorf("expected a Script object but got %T", obj)
+	}
+	return v.languages[script.Spec.Language], nil
+}
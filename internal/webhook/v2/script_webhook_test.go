@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scriptsv2 "github.com/veith4f/scropt/api/v2"
+)
+
+var _ = Describe("Script webhook", func() {
+	ctx := context.Background()
+	validator := newScriptValidator()
+
+	newScript := func(language scriptsv2.Language, code string) *scriptsv2.Script {
+		script := &scriptsv2.Script{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}
+		script.Spec.Language = language
+		script.Spec.Code = code
+		return script
+	}
+
+	It("should validate code of the language of the script", func() {
+		_, err := validator.ValidateCreate(ctx, newScript(scriptsv2.LanguageLua, `x = = 1`))
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring(`Script.scripts.scropt.io "example" is invalid`))
+
+		details := err.(apierrors.APIStatus).Status().Details
+		Expect(details.Group).To(Equal(scriptsv2.GroupVersion.Group))
+		Expect(details.Kind).To(Equal("Script"))
+	})
+
+	It("should validate unchanged code again if the language changed", func() {
+		old := newScript(scriptsv2.LanguageMoonScript, `x = = 1`)
+		_, err := validator.ValidateUpdate(ctx, old, newScript(scriptsv2.LanguageLua, `x = = 1`))
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+
+		_, err = validator.ValidateUpdate(ctx, old, old.DeepCopy())
+		Expect(err).NotTo(HaveOccurred())
+	})
+})